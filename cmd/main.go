@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
+	"time"
 
+	"github.com/go-redis/redis/v9"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
@@ -12,10 +15,12 @@ import (
 
 	"github.com/mirasildev/medium_post_service/config"
 	pb "github.com/mirasildev/medium_post_service/genproto/post_service"
-	"github.com/mirasildev/medium_post_service/pkg/logger"
+	"github.com/mirasildev/medium_post_service/pkg/auth"
 	grpcPkg "github.com/mirasildev/medium_post_service/pkg/grpc_client"
+	"github.com/mirasildev/medium_post_service/pkg/logger"
 	"github.com/mirasildev/medium_post_service/service"
 	"github.com/mirasildev/medium_post_service/storage"
+	"github.com/mirasildev/medium_post_service/worker"
 )
 
 func main() {
@@ -42,23 +47,76 @@ func main() {
 		log.Fatalf("failed to get grpc connections: %v", err)
 	}
 
-	postService := service.NewPostService(strg, logrus)
+	var (
+		inMemory storage.InMemoryStorageI
+		views    storage.ViewStorageI
+	)
+	if cfg.RedisAddr != "" {
+		rdb := redis.NewClient(&redis.Options{
+			Addr:     cfg.RedisAddr,
+			Password: cfg.RedisPassword,
+		})
+		inMemory = storage.NewInMemoryStorage(rdb)
+		views = storage.NewViewStorage(rdb)
+	}
+
+	if cfg.PostCacheEnabled {
+		if inMemory == nil {
+			log.Fatalf("post cache needs REDIS_ADDR")
+		}
+		strg = storage.NewCachedStorage(strg, inMemory, cfg.PostCacheTTL, logrus)
+	}
+
+	userLoader := service.NewUserLoader(grpcConn, inMemory, cfg.UserCacheTTL, cfg.UserLoaderConcurrency, logrus)
+
+	trustedProxies, err := service.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		log.Fatalf("failed to parse TRUSTED_PROXIES: %v", err)
+	}
+
+	postService := service.NewPostService(strg, views, cfg.ViewDedupeWindow, trustedProxies, logrus)
 	categoryService := service.NewCategoryService(strg, logrus)
-	commentService := service.NewCommentService(strg, logrus, grpcConn)
-	likeService := service.NewLikeService(strg, logrus)
+	commentService := service.NewCommentService(strg, logrus, userLoader)
+	likeService := service.NewLikeService(strg, logrus, userLoader)
+	tagService := service.NewTagService(strg, logrus)
+	feedService := service.NewFeedService(strg, logrus, userLoader)
+	bookmarkService := service.NewBookmarkService(strg, logrus)
+
+	publisher := worker.NewPublisher(strg, logrus, cfg.PublisherInterval, cfg.PublisherBatchSize)
+	go publisher.Run(context.Background())
+
+	trendingScorer := worker.NewTrendingScorer(strg, logrus, cfg.TrendingInterval)
+	go trendingScorer.Run(context.Background())
+
+	categoryStatsRefresher := worker.NewCategoryStatsRefresher(strg, logrus, cfg.CategoryStatsInterval)
+	go categoryStatsRefresher.Run(context.Background())
+
+	trashRetention := time.Duration(cfg.TrashRetentionDays) * 24 * time.Hour
+	trashPurger := worker.NewTrashPurger(strg, logrus, cfg.TrashPurgeInterval, trashRetention, cfg.TrashPurgeBatchSize)
+	go trashPurger.Run(context.Background())
+
+	if views != nil {
+		viewFlusher := worker.NewViewFlusher(strg, views, logrus, cfg.ViewFlushInterval, cfg.ViewFlushBatchSize)
+		go viewFlusher.Run(context.Background())
+	}
 
 	lis, err := net.Listen("tcp", cfg.GrpcPort)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(auth.NewInterceptor(grpcConn, logrus, service.Policies)),
+	)
 	reflection.Register(s)
 
 	pb.RegisterPostServiceServer(s, postService)
 	pb.RegisterCategoryServiceServer(s, categoryService)
 	pb.RegisterCommentServiceServer(s, commentService)
 	pb.RegisterLikeServiceServer(s, likeService)
+	pb.RegisterTagServiceServer(s, tagService)
+	pb.RegisterFeedServiceServer(s, feedService)
+	pb.RegisterBookmarkServiceServer(s, bookmarkService)
 
 	log.Println("Grpc server started in port ", cfg.GrpcPort)
 	if err := s.Serve(lis); err != nil {
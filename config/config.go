@@ -1,6 +1,9 @@
 package config
 
 import (
+	"strings"
+	"time"
+
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
 )
@@ -11,6 +14,36 @@ type Config struct {
 
 	UserServiceGrpcPort string
 	UserServiceHost     string
+
+	PublisherInterval  time.Duration
+	PublisherBatchSize int
+
+	// Redis is not used when RedisAddr is empty.
+	RedisAddr     string
+	RedisPassword string
+
+	UserCacheTTL          time.Duration
+	UserLoaderConcurrency int
+
+	// PostCacheEnabled needs RedisAddr.
+	PostCacheEnabled bool
+	PostCacheTTL     time.Duration
+
+	// The views of one viewer are counted once per ViewDedupeWindow.
+	ViewDedupeWindow   time.Duration
+	ViewFlushInterval  time.Duration
+	ViewFlushBatchSize int
+	// TrustedProxies are the IPs or CIDRs of the proxies whose forwarded
+	// client addresses are believed.
+	TrustedProxies []string
+
+	TrendingInterval      time.Duration
+	CategoryStatsInterval time.Duration
+
+	// Trashed posts are purged after TrashRetentionDays.
+	TrashRetentionDays  int
+	TrashPurgeInterval  time.Duration
+	TrashPurgeBatchSize int
 }
 
 type PostgresConfig struct {
@@ -27,6 +60,21 @@ func Load(path string) Config {
 	conf := viper.New()
 	conf.AutomaticEnv()
 
+	conf.SetDefault("PUBLISHER_INTERVAL", "1m")
+	conf.SetDefault("PUBLISHER_BATCH_SIZE", 100)
+	conf.SetDefault("USER_CACHE_TTL", "10m")
+	conf.SetDefault("USER_LOADER_CONCURRENCY", 8)
+	conf.SetDefault("POST_CACHE_ENABLED", false)
+	conf.SetDefault("POST_CACHE_TTL", "5m")
+	conf.SetDefault("VIEW_DEDUPE_WINDOW", "30m")
+	conf.SetDefault("VIEW_FLUSH_INTERVAL", "30s")
+	conf.SetDefault("VIEW_FLUSH_BATCH_SIZE", 500)
+	conf.SetDefault("TRENDING_INTERVAL", "5m")
+	conf.SetDefault("CATEGORY_STATS_INTERVAL", "10m")
+	conf.SetDefault("TRASH_RETENTION_DAYS", 30)
+	conf.SetDefault("TRASH_PURGE_INTERVAL", "1h")
+	conf.SetDefault("TRASH_PURGE_BATCH_SIZE", 100)
+
 	cfg := Config{
 		GrpcPort: conf.GetString("GRPC_PORT"),
 		Postgres: PostgresConfig{
@@ -38,6 +86,29 @@ func Load(path string) Config {
 		},
 		UserServiceHost:     conf.GetString("USER_SERVICE_HOST"),
 		UserServiceGrpcPort: conf.GetString("USER_SERVICE_GRPC_PORT"),
+		PublisherInterval:   conf.GetDuration("PUBLISHER_INTERVAL"),
+		PublisherBatchSize:  conf.GetInt("PUBLISHER_BATCH_SIZE"),
+
+		RedisAddr:     conf.GetString("REDIS_ADDR"),
+		RedisPassword: conf.GetString("REDIS_PASSWORD"),
+
+		UserCacheTTL:          conf.GetDuration("USER_CACHE_TTL"),
+		UserLoaderConcurrency: conf.GetInt("USER_LOADER_CONCURRENCY"),
+
+		PostCacheEnabled: conf.GetBool("POST_CACHE_ENABLED"),
+		PostCacheTTL:     conf.GetDuration("POST_CACHE_TTL"),
+
+		ViewDedupeWindow:   conf.GetDuration("VIEW_DEDUPE_WINDOW"),
+		ViewFlushInterval:  conf.GetDuration("VIEW_FLUSH_INTERVAL"),
+		ViewFlushBatchSize: conf.GetInt("VIEW_FLUSH_BATCH_SIZE"),
+		TrustedProxies:     strings.Split(conf.GetString("TRUSTED_PROXIES"), ","),
+
+		TrendingInterval:      conf.GetDuration("TRENDING_INTERVAL"),
+		CategoryStatsInterval: conf.GetDuration("CATEGORY_STATS_INTERVAL"),
+
+		TrashRetentionDays:  conf.GetInt("TRASH_RETENTION_DAYS"),
+		TrashPurgeInterval:  conf.GetDuration("TRASH_PURGE_INTERVAL"),
+		TrashPurgeBatchSize: conf.GetInt("TRASH_PURGE_BATCH_SIZE"),
 	}
 
 	return cfg
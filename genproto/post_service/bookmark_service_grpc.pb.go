@@ -0,0 +1,502 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: bookmark_service.proto
+
+package post_service
+
+import (
+	context "context"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// BookmarkServiceClient is the client API for BookmarkService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BookmarkServiceClient interface {
+	Add(ctx context.Context, in *BookmarkRequest, opts ...grpc.CallOption) (*Bookmark, error)
+	Remove(ctx context.Context, in *BookmarkRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	List(ctx context.Context, in *ListBookmarksRequest, opts ...grpc.CallOption) (*ListBookmarksResponse, error)
+	IsBookmarked(ctx context.Context, in *IsBookmarkedRequest, opts ...grpc.CallOption) (*IsBookmarkedResponse, error)
+	CreateReadingList(ctx context.Context, in *CreateReadingListRequest, opts ...grpc.CallOption) (*ReadingList, error)
+	UpdateReadingList(ctx context.Context, in *UpdateReadingListRequest, opts ...grpc.CallOption) (*ReadingList, error)
+	DeleteReadingList(ctx context.Context, in *GetReadingListRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetReadingList(ctx context.Context, in *GetReadingListRequest, opts ...grpc.CallOption) (*ReadingListPosts, error)
+	ListReadingLists(ctx context.Context, in *ListReadingListsRequest, opts ...grpc.CallOption) (*ListReadingListsResponse, error)
+	AddToReadingList(ctx context.Context, in *ReadingListPostRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	RemoveFromReadingList(ctx context.Context, in *ReadingListPostRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ReorderReadingList(ctx context.Context, in *ReorderReadingListRequest, opts ...grpc.CallOption) (*ReadingListPosts, error)
+}
+
+type bookmarkServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBookmarkServiceClient(cc grpc.ClientConnInterface) BookmarkServiceClient {
+	return &bookmarkServiceClient{cc}
+}
+
+func (c *bookmarkServiceClient) Add(ctx context.Context, in *BookmarkRequest, opts ...grpc.CallOption) (*Bookmark, error) {
+	out := new(Bookmark)
+	err := c.cc.Invoke(ctx, "/genproto.BookmarkService/Add", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkServiceClient) Remove(ctx context.Context, in *BookmarkRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/genproto.BookmarkService/Remove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkServiceClient) List(ctx context.Context, in *ListBookmarksRequest, opts ...grpc.CallOption) (*ListBookmarksResponse, error) {
+	out := new(ListBookmarksResponse)
+	err := c.cc.Invoke(ctx, "/genproto.BookmarkService/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkServiceClient) IsBookmarked(ctx context.Context, in *IsBookmarkedRequest, opts ...grpc.CallOption) (*IsBookmarkedResponse, error) {
+	out := new(IsBookmarkedResponse)
+	err := c.cc.Invoke(ctx, "/genproto.BookmarkService/IsBookmarked", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkServiceClient) CreateReadingList(ctx context.Context, in *CreateReadingListRequest, opts ...grpc.CallOption) (*ReadingList, error) {
+	out := new(ReadingList)
+	err := c.cc.Invoke(ctx, "/genproto.BookmarkService/CreateReadingList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkServiceClient) UpdateReadingList(ctx context.Context, in *UpdateReadingListRequest, opts ...grpc.CallOption) (*ReadingList, error) {
+	out := new(ReadingList)
+	err := c.cc.Invoke(ctx, "/genproto.BookmarkService/UpdateReadingList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkServiceClient) DeleteReadingList(ctx context.Context, in *GetReadingListRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/genproto.BookmarkService/DeleteReadingList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkServiceClient) GetReadingList(ctx context.Context, in *GetReadingListRequest, opts ...grpc.CallOption) (*ReadingListPosts, error) {
+	out := new(ReadingListPosts)
+	err := c.cc.Invoke(ctx, "/genproto.BookmarkService/GetReadingList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkServiceClient) ListReadingLists(ctx context.Context, in *ListReadingListsRequest, opts ...grpc.CallOption) (*ListReadingListsResponse, error) {
+	out := new(ListReadingListsResponse)
+	err := c.cc.Invoke(ctx, "/genproto.BookmarkService/ListReadingLists", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkServiceClient) AddToReadingList(ctx context.Context, in *ReadingListPostRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/genproto.BookmarkService/AddToReadingList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkServiceClient) RemoveFromReadingList(ctx context.Context, in *ReadingListPostRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/genproto.BookmarkService/RemoveFromReadingList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkServiceClient) ReorderReadingList(ctx context.Context, in *ReorderReadingListRequest, opts ...grpc.CallOption) (*ReadingListPosts, error) {
+	out := new(ReadingListPosts)
+	err := c.cc.Invoke(ctx, "/genproto.BookmarkService/ReorderReadingList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BookmarkServiceServer is the server API for BookmarkService service.
+// All implementations must embed UnimplementedBookmarkServiceServer
+// for forward compatibility
+type BookmarkServiceServer interface {
+	Add(context.Context, *BookmarkRequest) (*Bookmark, error)
+	Remove(context.Context, *BookmarkRequest) (*empty.Empty, error)
+	List(context.Context, *ListBookmarksRequest) (*ListBookmarksResponse, error)
+	IsBookmarked(context.Context, *IsBookmarkedRequest) (*IsBookmarkedResponse, error)
+	CreateReadingList(context.Context, *CreateReadingListRequest) (*ReadingList, error)
+	UpdateReadingList(context.Context, *UpdateReadingListRequest) (*ReadingList, error)
+	DeleteReadingList(context.Context, *GetReadingListRequest) (*empty.Empty, error)
+	GetReadingList(context.Context, *GetReadingListRequest) (*ReadingListPosts, error)
+	ListReadingLists(context.Context, *ListReadingListsRequest) (*ListReadingListsResponse, error)
+	AddToReadingList(context.Context, *ReadingListPostRequest) (*empty.Empty, error)
+	RemoveFromReadingList(context.Context, *ReadingListPostRequest) (*empty.Empty, error)
+	ReorderReadingList(context.Context, *ReorderReadingListRequest) (*ReadingListPosts, error)
+	mustEmbedUnimplementedBookmarkServiceServer()
+}
+
+// UnimplementedBookmarkServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedBookmarkServiceServer struct {
+}
+
+func (UnimplementedBookmarkServiceServer) Add(context.Context, *BookmarkRequest) (*Bookmark, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Add not implemented")
+}
+func (UnimplementedBookmarkServiceServer) Remove(context.Context, *BookmarkRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
+}
+func (UnimplementedBookmarkServiceServer) List(context.Context, *ListBookmarksRequest) (*ListBookmarksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedBookmarkServiceServer) IsBookmarked(context.Context, *IsBookmarkedRequest) (*IsBookmarkedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsBookmarked not implemented")
+}
+func (UnimplementedBookmarkServiceServer) CreateReadingList(context.Context, *CreateReadingListRequest) (*ReadingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReadingList not implemented")
+}
+func (UnimplementedBookmarkServiceServer) UpdateReadingList(context.Context, *UpdateReadingListRequest) (*ReadingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateReadingList not implemented")
+}
+func (UnimplementedBookmarkServiceServer) DeleteReadingList(context.Context, *GetReadingListRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteReadingList not implemented")
+}
+func (UnimplementedBookmarkServiceServer) GetReadingList(context.Context, *GetReadingListRequest) (*ReadingListPosts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReadingList not implemented")
+}
+func (UnimplementedBookmarkServiceServer) ListReadingLists(context.Context, *ListReadingListsRequest) (*ListReadingListsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReadingLists not implemented")
+}
+func (UnimplementedBookmarkServiceServer) AddToReadingList(context.Context, *ReadingListPostRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddToReadingList not implemented")
+}
+func (UnimplementedBookmarkServiceServer) RemoveFromReadingList(context.Context, *ReadingListPostRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromReadingList not implemented")
+}
+func (UnimplementedBookmarkServiceServer) ReorderReadingList(context.Context, *ReorderReadingListRequest) (*ReadingListPosts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderReadingList not implemented")
+}
+func (UnimplementedBookmarkServiceServer) mustEmbedUnimplementedBookmarkServiceServer() {}
+
+// UnsafeBookmarkServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BookmarkServiceServer will
+// result in compilation errors.
+type UnsafeBookmarkServiceServer interface {
+	mustEmbedUnimplementedBookmarkServiceServer()
+}
+
+func RegisterBookmarkServiceServer(s grpc.ServiceRegistrar, srv BookmarkServiceServer) {
+	s.RegisterService(&BookmarkService_ServiceDesc, srv)
+}
+
+func _BookmarkService_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookmarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkServiceServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.BookmarkService/Add",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkServiceServer).Add(ctx, req.(*BookmarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkService_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookmarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkServiceServer).Remove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.BookmarkService/Remove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkServiceServer).Remove(ctx, req.(*BookmarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBookmarksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.BookmarkService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkServiceServer).List(ctx, req.(*ListBookmarksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkService_IsBookmarked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsBookmarkedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkServiceServer).IsBookmarked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.BookmarkService/IsBookmarked",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkServiceServer).IsBookmarked(ctx, req.(*IsBookmarkedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkService_CreateReadingList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReadingListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkServiceServer).CreateReadingList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.BookmarkService/CreateReadingList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkServiceServer).CreateReadingList(ctx, req.(*CreateReadingListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkService_UpdateReadingList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateReadingListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkServiceServer).UpdateReadingList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.BookmarkService/UpdateReadingList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkServiceServer).UpdateReadingList(ctx, req.(*UpdateReadingListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkService_DeleteReadingList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReadingListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkServiceServer).DeleteReadingList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.BookmarkService/DeleteReadingList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkServiceServer).DeleteReadingList(ctx, req.(*GetReadingListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkService_GetReadingList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReadingListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkServiceServer).GetReadingList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.BookmarkService/GetReadingList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkServiceServer).GetReadingList(ctx, req.(*GetReadingListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkService_ListReadingLists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReadingListsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkServiceServer).ListReadingLists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.BookmarkService/ListReadingLists",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkServiceServer).ListReadingLists(ctx, req.(*ListReadingListsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkService_AddToReadingList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadingListPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkServiceServer).AddToReadingList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.BookmarkService/AddToReadingList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkServiceServer).AddToReadingList(ctx, req.(*ReadingListPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkService_RemoveFromReadingList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadingListPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkServiceServer).RemoveFromReadingList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.BookmarkService/RemoveFromReadingList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkServiceServer).RemoveFromReadingList(ctx, req.(*ReadingListPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkService_ReorderReadingList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderReadingListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkServiceServer).ReorderReadingList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.BookmarkService/ReorderReadingList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkServiceServer).ReorderReadingList(ctx, req.(*ReorderReadingListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BookmarkService_ServiceDesc is the grpc.ServiceDesc for BookmarkService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BookmarkService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "genproto.BookmarkService",
+	HandlerType: (*BookmarkServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Add",
+			Handler:    _BookmarkService_Add_Handler,
+		},
+		{
+			MethodName: "Remove",
+			Handler:    _BookmarkService_Remove_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _BookmarkService_List_Handler,
+		},
+		{
+			MethodName: "IsBookmarked",
+			Handler:    _BookmarkService_IsBookmarked_Handler,
+		},
+		{
+			MethodName: "CreateReadingList",
+			Handler:    _BookmarkService_CreateReadingList_Handler,
+		},
+		{
+			MethodName: "UpdateReadingList",
+			Handler:    _BookmarkService_UpdateReadingList_Handler,
+		},
+		{
+			MethodName: "DeleteReadingList",
+			Handler:    _BookmarkService_DeleteReadingList_Handler,
+		},
+		{
+			MethodName: "GetReadingList",
+			Handler:    _BookmarkService_GetReadingList_Handler,
+		},
+		{
+			MethodName: "ListReadingLists",
+			Handler:    _BookmarkService_ListReadingLists_Handler,
+		},
+		{
+			MethodName: "AddToReadingList",
+			Handler:    _BookmarkService_AddToReadingList_Handler,
+		},
+		{
+			MethodName: "RemoveFromReadingList",
+			Handler:    _BookmarkService_RemoveFromReadingList_Handler,
+		},
+		{
+			MethodName: "ReorderReadingList",
+			Handler:    _BookmarkService_ReorderReadingList_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bookmark_service.proto",
+}
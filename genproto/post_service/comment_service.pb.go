@@ -27,7 +27,7 @@ var file_comment_service_proto_rawDesc = []byte{
 	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x1a, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xaf, 0x02,
+	0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xfa, 0x02,
 	0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
 	0x12, 0x30, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x11, 0x2e, 0x67, 0x65, 0x6e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x11, 0x2e,
@@ -46,17 +46,24 @@ var file_comment_service_proto_rawDesc = []byte{
 	0x65, 0x6e, 0x74, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12,
 	0x14, 0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f,
 	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x42,
-	0x17, 0x5a, 0x15, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x6f, 0x73, 0x74,
-	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12,
+	0x49, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x12, 0x21, 0x2e, 0x67,
+	0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x22, 0x00, 0x42, 0x17, 0x5a, 0x15, 0x67, 0x65,
+	0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_comment_service_proto_goTypes = []interface{}{
-	(*Comment)(nil),                // 0: genproto.Comment
-	(*GetComment)(nil),             // 1: genproto.GetComment
-	(*GetAllCommentsRequest)(nil),  // 2: genproto.GetAllCommentsRequest
-	(*GetAllCommentsResponse)(nil), // 3: genproto.GetAllCommentsResponse
-	(*empty.Empty)(nil),            // 4: google.protobuf.Empty
+	(*Comment)(nil),                 // 0: genproto.Comment
+	(*GetComment)(nil),              // 1: genproto.GetComment
+	(*GetAllCommentsRequest)(nil),   // 2: genproto.GetAllCommentsRequest
+	(*GetCommentThreadRequest)(nil), // 3: genproto.GetCommentThreadRequest
+	(*GetAllCommentsResponse)(nil),  // 4: genproto.GetAllCommentsResponse
+	(*empty.Empty)(nil),             // 5: google.protobuf.Empty
+	(*CommentThread)(nil),           // 6: genproto.CommentThread
 }
 var file_comment_service_proto_depIdxs = []int32{
 	0, // 0: genproto.CommentService.Create:input_type -> genproto.Comment
@@ -64,13 +71,15 @@ var file_comment_service_proto_depIdxs = []int32{
 	2, // 2: genproto.CommentService.GetAll:input_type -> genproto.GetAllCommentsRequest
 	0, // 3: genproto.CommentService.Update:input_type -> genproto.Comment
 	1, // 4: genproto.CommentService.Delete:input_type -> genproto.GetComment
-	0, // 5: genproto.CommentService.Create:output_type -> genproto.Comment
-	0, // 6: genproto.CommentService.Get:output_type -> genproto.Comment
-	3, // 7: genproto.CommentService.GetAll:output_type -> genproto.GetAllCommentsResponse
-	0, // 8: genproto.CommentService.Update:output_type -> genproto.Comment
-	4, // 9: genproto.CommentService.Delete:output_type -> google.protobuf.Empty
-	5, // [5:10] is the sub-list for method output_type
-	0, // [0:5] is the sub-list for method input_type
+	3, // 5: genproto.CommentService.GetThread:input_type -> genproto.GetCommentThreadRequest
+	0, // 6: genproto.CommentService.Create:output_type -> genproto.Comment
+	0, // 7: genproto.CommentService.Get:output_type -> genproto.Comment
+	4, // 8: genproto.CommentService.GetAll:output_type -> genproto.GetAllCommentsResponse
+	0, // 9: genproto.CommentService.Update:output_type -> genproto.Comment
+	5, // 10: genproto.CommentService.Delete:output_type -> google.protobuf.Empty
+	6, // 11: genproto.CommentService.GetThread:output_type -> genproto.CommentThread
+	6, // [6:12] is the sub-list for method output_type
+	0, // [0:6] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -28,6 +28,7 @@ type CommentServiceClient interface {
 	GetAll(ctx context.Context, in *GetAllCommentsRequest, opts ...grpc.CallOption) (*GetAllCommentsResponse, error)
 	Update(ctx context.Context, in *Comment, opts ...grpc.CallOption) (*Comment, error)
 	Delete(ctx context.Context, in *GetComment, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetThread(ctx context.Context, in *GetCommentThreadRequest, opts ...grpc.CallOption) (*CommentThread, error)
 }
 
 type commentServiceClient struct {
@@ -83,6 +84,15 @@ func (c *commentServiceClient) Delete(ctx context.Context, in *GetComment, opts
 	return out, nil
 }
 
+func (c *commentServiceClient) GetThread(ctx context.Context, in *GetCommentThreadRequest, opts ...grpc.CallOption) (*CommentThread, error) {
+	out := new(CommentThread)
+	err := c.cc.Invoke(ctx, "/genproto.CommentService/GetThread", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommentServiceServer is the server API for CommentService service.
 // All implementations must embed UnimplementedCommentServiceServer
 // for forward compatibility
@@ -92,6 +102,7 @@ type CommentServiceServer interface {
 	GetAll(context.Context, *GetAllCommentsRequest) (*GetAllCommentsResponse, error)
 	Update(context.Context, *Comment) (*Comment, error)
 	Delete(context.Context, *GetComment) (*empty.Empty, error)
+	GetThread(context.Context, *GetCommentThreadRequest) (*CommentThread, error)
 	mustEmbedUnimplementedCommentServiceServer()
 }
 
@@ -114,6 +125,9 @@ func (UnimplementedCommentServiceServer) Update(context.Context, *Comment) (*Com
 func (UnimplementedCommentServiceServer) Delete(context.Context, *GetComment) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedCommentServiceServer) GetThread(context.Context, *GetCommentThreadRequest) (*CommentThread, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThread not implemented")
+}
 func (UnimplementedCommentServiceServer) mustEmbedUnimplementedCommentServiceServer() {}
 
 // UnsafeCommentServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -217,6 +231,24 @@ func _CommentService_Delete_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommentService_GetThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommentThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).GetThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.CommentService/GetThread",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).GetThread(ctx, req.(*GetCommentThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CommentService_ServiceDesc is the grpc.ServiceDesc for CommentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -244,6 +276,10 @@ var CommentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _CommentService_Delete_Handler,
 		},
+		{
+			MethodName: "GetThread",
+			Handler:    _CommentService_GetThread_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "comment_service.proto",
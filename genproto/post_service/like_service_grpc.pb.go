@@ -8,6 +8,7 @@ package post_service
 
 import (
 	context "context"
+	empty "github.com/golang/protobuf/ptypes/empty"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -23,8 +24,12 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LikeServiceClient interface {
 	CreateOrUpdate(ctx context.Context, in *Like, opts ...grpc.CallOption) (*Like, error)
+	SetReaction(ctx context.Context, in *SetReactionRequest, opts ...grpc.CallOption) (*Like, error)
+	RemoveReaction(ctx context.Context, in *RemoveReactionRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	Get(ctx context.Context, in *GetLike, opts ...grpc.CallOption) (*Like, error)
 	GetAllLikesCount(ctx context.Context, in *GetLike, opts ...grpc.CallOption) (*AllLikesCount, error)
+	ListLikers(ctx context.Context, in *ListLikersRequest, opts ...grpc.CallOption) (*ListLikersResponse, error)
+	ListLikedPosts(ctx context.Context, in *ListLikedPostsRequest, opts ...grpc.CallOption) (*GetAllPostsResponse, error)
 }
 
 type likeServiceClient struct {
@@ -44,6 +49,24 @@ func (c *likeServiceClient) CreateOrUpdate(ctx context.Context, in *Like, opts .
 	return out, nil
 }
 
+func (c *likeServiceClient) SetReaction(ctx context.Context, in *SetReactionRequest, opts ...grpc.CallOption) (*Like, error) {
+	out := new(Like)
+	err := c.cc.Invoke(ctx, "/genproto.LikeService/SetReaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *likeServiceClient) RemoveReaction(ctx context.Context, in *RemoveReactionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/genproto.LikeService/RemoveReaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *likeServiceClient) Get(ctx context.Context, in *GetLike, opts ...grpc.CallOption) (*Like, error) {
 	out := new(Like)
 	err := c.cc.Invoke(ctx, "/genproto.LikeService/Get", in, out, opts...)
@@ -62,13 +85,35 @@ func (c *likeServiceClient) GetAllLikesCount(ctx context.Context, in *GetLike, o
 	return out, nil
 }
 
+func (c *likeServiceClient) ListLikers(ctx context.Context, in *ListLikersRequest, opts ...grpc.CallOption) (*ListLikersResponse, error) {
+	out := new(ListLikersResponse)
+	err := c.cc.Invoke(ctx, "/genproto.LikeService/ListLikers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *likeServiceClient) ListLikedPosts(ctx context.Context, in *ListLikedPostsRequest, opts ...grpc.CallOption) (*GetAllPostsResponse, error) {
+	out := new(GetAllPostsResponse)
+	err := c.cc.Invoke(ctx, "/genproto.LikeService/ListLikedPosts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LikeServiceServer is the server API for LikeService service.
 // All implementations must embed UnimplementedLikeServiceServer
 // for forward compatibility
 type LikeServiceServer interface {
 	CreateOrUpdate(context.Context, *Like) (*Like, error)
+	SetReaction(context.Context, *SetReactionRequest) (*Like, error)
+	RemoveReaction(context.Context, *RemoveReactionRequest) (*empty.Empty, error)
 	Get(context.Context, *GetLike) (*Like, error)
 	GetAllLikesCount(context.Context, *GetLike) (*AllLikesCount, error)
+	ListLikers(context.Context, *ListLikersRequest) (*ListLikersResponse, error)
+	ListLikedPosts(context.Context, *ListLikedPostsRequest) (*GetAllPostsResponse, error)
 	mustEmbedUnimplementedLikeServiceServer()
 }
 
@@ -79,12 +124,24 @@ type UnimplementedLikeServiceServer struct {
 func (UnimplementedLikeServiceServer) CreateOrUpdate(context.Context, *Like) (*Like, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateOrUpdate not implemented")
 }
+func (UnimplementedLikeServiceServer) SetReaction(context.Context, *SetReactionRequest) (*Like, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReaction not implemented")
+}
+func (UnimplementedLikeServiceServer) RemoveReaction(context.Context, *RemoveReactionRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveReaction not implemented")
+}
 func (UnimplementedLikeServiceServer) Get(context.Context, *GetLike) (*Like, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
 func (UnimplementedLikeServiceServer) GetAllLikesCount(context.Context, *GetLike) (*AllLikesCount, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllLikesCount not implemented")
 }
+func (UnimplementedLikeServiceServer) ListLikers(context.Context, *ListLikersRequest) (*ListLikersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLikers not implemented")
+}
+func (UnimplementedLikeServiceServer) ListLikedPosts(context.Context, *ListLikedPostsRequest) (*GetAllPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLikedPosts not implemented")
+}
 func (UnimplementedLikeServiceServer) mustEmbedUnimplementedLikeServiceServer() {}
 
 // UnsafeLikeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -116,6 +173,42 @@ func _LikeService_CreateOrUpdate_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LikeService_SetReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LikeServiceServer).SetReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.LikeService/SetReaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LikeServiceServer).SetReaction(ctx, req.(*SetReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LikeService_RemoveReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LikeServiceServer).RemoveReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.LikeService/RemoveReaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LikeServiceServer).RemoveReaction(ctx, req.(*RemoveReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LikeService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetLike)
 	if err := dec(in); err != nil {
@@ -152,6 +245,42 @@ func _LikeService_GetAllLikesCount_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LikeService_ListLikers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLikersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LikeServiceServer).ListLikers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.LikeService/ListLikers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LikeServiceServer).ListLikers(ctx, req.(*ListLikersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LikeService_ListLikedPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLikedPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LikeServiceServer).ListLikedPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.LikeService/ListLikedPosts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LikeServiceServer).ListLikedPosts(ctx, req.(*ListLikedPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LikeService_ServiceDesc is the grpc.ServiceDesc for LikeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -163,6 +292,14 @@ var LikeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateOrUpdate",
 			Handler:    _LikeService_CreateOrUpdate_Handler,
 		},
+		{
+			MethodName: "SetReaction",
+			Handler:    _LikeService_SetReaction_Handler,
+		},
+		{
+			MethodName: "RemoveReaction",
+			Handler:    _LikeService_RemoveReaction_Handler,
+		},
 		{
 			MethodName: "Get",
 			Handler:    _LikeService_Get_Handler,
@@ -171,6 +308,14 @@ var LikeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllLikesCount",
 			Handler:    _LikeService_GetAllLikesCount_Handler,
 		},
+		{
+			MethodName: "ListLikers",
+			Handler:    _LikeService_ListLikers_Handler,
+		},
+		{
+			MethodName: "ListLikedPosts",
+			Handler:    _LikeService_ListLikedPosts_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "like_service.proto",
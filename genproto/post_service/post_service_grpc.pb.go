@@ -25,9 +25,25 @@ const _ = grpc.SupportPackageIsVersion7
 type PostServiceClient interface {
 	Create(ctx context.Context, in *Post, opts ...grpc.CallOption) (*Post, error)
 	Get(ctx context.Context, in *GetPost, opts ...grpc.CallOption) (*Post, error)
+	GetBySlug(ctx context.Context, in *GetPostBySlugRequest, opts ...grpc.CallOption) (*PostBySlug, error)
+	GetByPublicId(ctx context.Context, in *GetPostByPublicIdRequest, opts ...grpc.CallOption) (*Post, error)
 	GetAll(ctx context.Context, in *GetAllPostsRequest, opts ...grpc.CallOption) (*GetAllPostsResponse, error)
+	SearchPosts(ctx context.Context, in *SearchPostsRequest, opts ...grpc.CallOption) (*SearchPostsResponse, error)
 	Update(ctx context.Context, in *UpdatePostRequest, opts ...grpc.CallOption) (*Post, error)
 	Delete(ctx context.Context, in *DeletePost, opts ...grpc.CallOption) (*empty.Empty, error)
+	Restore(ctx context.Context, in *ChangePostStatusRequest, opts ...grpc.CallOption) (*Post, error)
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*GetAllPostsResponse, error)
+	Publish(ctx context.Context, in *ChangePostStatusRequest, opts ...grpc.CallOption) (*Post, error)
+	Unpublish(ctx context.Context, in *ChangePostStatusRequest, opts ...grpc.CallOption) (*Post, error)
+	Archive(ctx context.Context, in *ChangePostStatusRequest, opts ...grpc.CallOption) (*Post, error)
+	SchedulePublish(ctx context.Context, in *SchedulePostRequest, opts ...grpc.CallOption) (*Post, error)
+	ReschedulePublish(ctx context.Context, in *SchedulePostRequest, opts ...grpc.CallOption) (*Post, error)
+	CancelScheduledPublish(ctx context.Context, in *ChangePostStatusRequest, opts ...grpc.CallOption) (*Post, error)
+	ListRevisions(ctx context.Context, in *ListPostRevisionsRequest, opts ...grpc.CallOption) (*ListPostRevisionsResponse, error)
+	GetRevision(ctx context.Context, in *GetPostRevisionRequest, opts ...grpc.CallOption) (*PostRevision, error)
+	DiffRevisions(ctx context.Context, in *DiffPostRevisionsRequest, opts ...grpc.CallOption) (*PostRevisionDiff, error)
+	RestoreRevision(ctx context.Context, in *RestorePostRevisionRequest, opts ...grpc.CallOption) (*Post, error)
+	RecountCounters(ctx context.Context, in *RecountPostCountersRequest, opts ...grpc.CallOption) (*RecountPostCountersResponse, error)
 }
 
 type postServiceClient struct {
@@ -56,6 +72,24 @@ func (c *postServiceClient) Get(ctx context.Context, in *GetPost, opts ...grpc.C
 	return out, nil
 }
 
+func (c *postServiceClient) GetBySlug(ctx context.Context, in *GetPostBySlugRequest, opts ...grpc.CallOption) (*PostBySlug, error) {
+	out := new(PostBySlug)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/GetBySlug", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) GetByPublicId(ctx context.Context, in *GetPostByPublicIdRequest, opts ...grpc.CallOption) (*Post, error) {
+	out := new(Post)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/GetByPublicId", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *postServiceClient) GetAll(ctx context.Context, in *GetAllPostsRequest, opts ...grpc.CallOption) (*GetAllPostsResponse, error) {
 	out := new(GetAllPostsResponse)
 	err := c.cc.Invoke(ctx, "/genproto.PostService/GetAll", in, out, opts...)
@@ -65,6 +99,15 @@ func (c *postServiceClient) GetAll(ctx context.Context, in *GetAllPostsRequest,
 	return out, nil
 }
 
+func (c *postServiceClient) SearchPosts(ctx context.Context, in *SearchPostsRequest, opts ...grpc.CallOption) (*SearchPostsResponse, error) {
+	out := new(SearchPostsResponse)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/SearchPosts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *postServiceClient) Update(ctx context.Context, in *UpdatePostRequest, opts ...grpc.CallOption) (*Post, error) {
 	out := new(Post)
 	err := c.cc.Invoke(ctx, "/genproto.PostService/Update", in, out, opts...)
@@ -83,15 +126,148 @@ func (c *postServiceClient) Delete(ctx context.Context, in *DeletePost, opts ...
 	return out, nil
 }
 
+func (c *postServiceClient) Restore(ctx context.Context, in *ChangePostStatusRequest, opts ...grpc.CallOption) (*Post, error) {
+	out := new(Post)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*GetAllPostsResponse, error) {
+	out := new(GetAllPostsResponse)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/ListTrash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) Publish(ctx context.Context, in *ChangePostStatusRequest, opts ...grpc.CallOption) (*Post, error) {
+	out := new(Post)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/Publish", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) Unpublish(ctx context.Context, in *ChangePostStatusRequest, opts ...grpc.CallOption) (*Post, error) {
+	out := new(Post)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/Unpublish", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) Archive(ctx context.Context, in *ChangePostStatusRequest, opts ...grpc.CallOption) (*Post, error) {
+	out := new(Post)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/Archive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) SchedulePublish(ctx context.Context, in *SchedulePostRequest, opts ...grpc.CallOption) (*Post, error) {
+	out := new(Post)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/SchedulePublish", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) ReschedulePublish(ctx context.Context, in *SchedulePostRequest, opts ...grpc.CallOption) (*Post, error) {
+	out := new(Post)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/ReschedulePublish", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) CancelScheduledPublish(ctx context.Context, in *ChangePostStatusRequest, opts ...grpc.CallOption) (*Post, error) {
+	out := new(Post)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/CancelScheduledPublish", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) ListRevisions(ctx context.Context, in *ListPostRevisionsRequest, opts ...grpc.CallOption) (*ListPostRevisionsResponse, error) {
+	out := new(ListPostRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/ListRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) GetRevision(ctx context.Context, in *GetPostRevisionRequest, opts ...grpc.CallOption) (*PostRevision, error) {
+	out := new(PostRevision)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/GetRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) DiffRevisions(ctx context.Context, in *DiffPostRevisionsRequest, opts ...grpc.CallOption) (*PostRevisionDiff, error) {
+	out := new(PostRevisionDiff)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/DiffRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) RestoreRevision(ctx context.Context, in *RestorePostRevisionRequest, opts ...grpc.CallOption) (*Post, error) {
+	out := new(Post)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/RestoreRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) RecountCounters(ctx context.Context, in *RecountPostCountersRequest, opts ...grpc.CallOption) (*RecountPostCountersResponse, error) {
+	out := new(RecountPostCountersResponse)
+	err := c.cc.Invoke(ctx, "/genproto.PostService/RecountCounters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PostServiceServer is the server API for PostService service.
 // All implementations must embed UnimplementedPostServiceServer
 // for forward compatibility
 type PostServiceServer interface {
 	Create(context.Context, *Post) (*Post, error)
 	Get(context.Context, *GetPost) (*Post, error)
+	GetBySlug(context.Context, *GetPostBySlugRequest) (*PostBySlug, error)
+	GetByPublicId(context.Context, *GetPostByPublicIdRequest) (*Post, error)
 	GetAll(context.Context, *GetAllPostsRequest) (*GetAllPostsResponse, error)
+	SearchPosts(context.Context, *SearchPostsRequest) (*SearchPostsResponse, error)
 	Update(context.Context, *UpdatePostRequest) (*Post, error)
 	Delete(context.Context, *DeletePost) (*empty.Empty, error)
+	Restore(context.Context, *ChangePostStatusRequest) (*Post, error)
+	ListTrash(context.Context, *ListTrashRequest) (*GetAllPostsResponse, error)
+	Publish(context.Context, *ChangePostStatusRequest) (*Post, error)
+	Unpublish(context.Context, *ChangePostStatusRequest) (*Post, error)
+	Archive(context.Context, *ChangePostStatusRequest) (*Post, error)
+	SchedulePublish(context.Context, *SchedulePostRequest) (*Post, error)
+	ReschedulePublish(context.Context, *SchedulePostRequest) (*Post, error)
+	CancelScheduledPublish(context.Context, *ChangePostStatusRequest) (*Post, error)
+	ListRevisions(context.Context, *ListPostRevisionsRequest) (*ListPostRevisionsResponse, error)
+	GetRevision(context.Context, *GetPostRevisionRequest) (*PostRevision, error)
+	DiffRevisions(context.Context, *DiffPostRevisionsRequest) (*PostRevisionDiff, error)
+	RestoreRevision(context.Context, *RestorePostRevisionRequest) (*Post, error)
+	RecountCounters(context.Context, *RecountPostCountersRequest) (*RecountPostCountersResponse, error)
 	mustEmbedUnimplementedPostServiceServer()
 }
 
@@ -105,15 +281,63 @@ func (UnimplementedPostServiceServer) Create(context.Context, *Post) (*Post, err
 func (UnimplementedPostServiceServer) Get(context.Context, *GetPost) (*Post, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (UnimplementedPostServiceServer) GetBySlug(context.Context, *GetPostBySlugRequest) (*PostBySlug, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBySlug not implemented")
+}
+func (UnimplementedPostServiceServer) GetByPublicId(context.Context, *GetPostByPublicIdRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByPublicId not implemented")
+}
 func (UnimplementedPostServiceServer) GetAll(context.Context, *GetAllPostsRequest) (*GetAllPostsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
 }
+func (UnimplementedPostServiceServer) SearchPosts(context.Context, *SearchPostsRequest) (*SearchPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchPosts not implemented")
+}
 func (UnimplementedPostServiceServer) Update(context.Context, *UpdatePostRequest) (*Post, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
 }
 func (UnimplementedPostServiceServer) Delete(context.Context, *DeletePost) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedPostServiceServer) Restore(context.Context, *ChangePostStatusRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedPostServiceServer) ListTrash(context.Context, *ListTrashRequest) (*GetAllPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedPostServiceServer) Publish(context.Context, *ChangePostStatusRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
+}
+func (UnimplementedPostServiceServer) Unpublish(context.Context, *ChangePostStatusRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unpublish not implemented")
+}
+func (UnimplementedPostServiceServer) Archive(context.Context, *ChangePostStatusRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Archive not implemented")
+}
+func (UnimplementedPostServiceServer) SchedulePublish(context.Context, *SchedulePostRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SchedulePublish not implemented")
+}
+func (UnimplementedPostServiceServer) ReschedulePublish(context.Context, *SchedulePostRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReschedulePublish not implemented")
+}
+func (UnimplementedPostServiceServer) CancelScheduledPublish(context.Context, *ChangePostStatusRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledPublish not implemented")
+}
+func (UnimplementedPostServiceServer) ListRevisions(context.Context, *ListPostRevisionsRequest) (*ListPostRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRevisions not implemented")
+}
+func (UnimplementedPostServiceServer) GetRevision(context.Context, *GetPostRevisionRequest) (*PostRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevision not implemented")
+}
+func (UnimplementedPostServiceServer) DiffRevisions(context.Context, *DiffPostRevisionsRequest) (*PostRevisionDiff, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffRevisions not implemented")
+}
+func (UnimplementedPostServiceServer) RestoreRevision(context.Context, *RestorePostRevisionRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreRevision not implemented")
+}
+func (UnimplementedPostServiceServer) RecountCounters(context.Context, *RecountPostCountersRequest) (*RecountPostCountersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecountCounters not implemented")
+}
 func (UnimplementedPostServiceServer) mustEmbedUnimplementedPostServiceServer() {}
 
 // UnsafePostServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -163,6 +387,42 @@ func _PostService_Get_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_GetBySlug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostBySlugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).GetBySlug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/GetBySlug",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).GetBySlug(ctx, req.(*GetPostBySlugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_GetByPublicId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostByPublicIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).GetByPublicId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/GetByPublicId",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).GetByPublicId(ctx, req.(*GetPostByPublicIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PostService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetAllPostsRequest)
 	if err := dec(in); err != nil {
@@ -181,6 +441,24 @@ func _PostService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_SearchPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).SearchPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/SearchPosts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).SearchPosts(ctx, req.(*SearchPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PostService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdatePostRequest)
 	if err := dec(in); err != nil {
@@ -217,6 +495,240 @@ func _PostService_Delete_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePostStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).Restore(ctx, req.(*ChangePostStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/ListTrash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).ListTrash(ctx, req.(*ListTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePostStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/Publish",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).Publish(ctx, req.(*ChangePostStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_Unpublish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePostStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).Unpublish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/Unpublish",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).Unpublish(ctx, req.(*ChangePostStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_Archive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePostStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).Archive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/Archive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).Archive(ctx, req.(*ChangePostStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_SchedulePublish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).SchedulePublish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/SchedulePublish",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).SchedulePublish(ctx, req.(*SchedulePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_ReschedulePublish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).ReschedulePublish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/ReschedulePublish",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).ReschedulePublish(ctx, req.(*SchedulePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_CancelScheduledPublish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePostStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).CancelScheduledPublish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/CancelScheduledPublish",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).CancelScheduledPublish(ctx, req.(*ChangePostStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_ListRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPostRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).ListRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/ListRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).ListRevisions(ctx, req.(*ListPostRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_GetRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).GetRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/GetRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).GetRevision(ctx, req.(*GetPostRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_DiffRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffPostRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).DiffRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/DiffRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).DiffRevisions(ctx, req.(*DiffPostRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_RestoreRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestorePostRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).RestoreRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/RestoreRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).RestoreRevision(ctx, req.(*RestorePostRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_RecountCounters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecountPostCountersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).RecountCounters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.PostService/RecountCounters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).RecountCounters(ctx, req.(*RecountPostCountersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PostService_ServiceDesc is the grpc.ServiceDesc for PostService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -232,10 +744,22 @@ var PostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _PostService_Get_Handler,
 		},
+		{
+			MethodName: "GetBySlug",
+			Handler:    _PostService_GetBySlug_Handler,
+		},
+		{
+			MethodName: "GetByPublicId",
+			Handler:    _PostService_GetByPublicId_Handler,
+		},
 		{
 			MethodName: "GetAll",
 			Handler:    _PostService_GetAll_Handler,
 		},
+		{
+			MethodName: "SearchPosts",
+			Handler:    _PostService_SearchPosts_Handler,
+		},
 		{
 			MethodName: "Update",
 			Handler:    _PostService_Update_Handler,
@@ -244,6 +768,58 @@ var PostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _PostService_Delete_Handler,
 		},
+		{
+			MethodName: "Restore",
+			Handler:    _PostService_Restore_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _PostService_ListTrash_Handler,
+		},
+		{
+			MethodName: "Publish",
+			Handler:    _PostService_Publish_Handler,
+		},
+		{
+			MethodName: "Unpublish",
+			Handler:    _PostService_Unpublish_Handler,
+		},
+		{
+			MethodName: "Archive",
+			Handler:    _PostService_Archive_Handler,
+		},
+		{
+			MethodName: "SchedulePublish",
+			Handler:    _PostService_SchedulePublish_Handler,
+		},
+		{
+			MethodName: "ReschedulePublish",
+			Handler:    _PostService_ReschedulePublish_Handler,
+		},
+		{
+			MethodName: "CancelScheduledPublish",
+			Handler:    _PostService_CancelScheduledPublish_Handler,
+		},
+		{
+			MethodName: "ListRevisions",
+			Handler:    _PostService_ListRevisions_Handler,
+		},
+		{
+			MethodName: "GetRevision",
+			Handler:    _PostService_GetRevision_Handler,
+		},
+		{
+			MethodName: "DiffRevisions",
+			Handler:    _PostService_DiffRevisions_Handler,
+		},
+		{
+			MethodName: "RestoreRevision",
+			Handler:    _PostService_RestoreRevision_Handler,
+		},
+		{
+			MethodName: "RecountCounters",
+			Handler:    _PostService_RecountCounters_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "post_service.proto",
@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: tag_service.proto
+
+package post_service
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// TagServiceClient is the client API for TagService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TagServiceClient interface {
+	SetPostTags(ctx context.Context, in *SetPostTagsRequest, opts ...grpc.CallOption) (*PostTags, error)
+	GetPostTags(ctx context.Context, in *GetPostTagsRequest, opts ...grpc.CallOption) (*PostTags, error)
+	GetAll(ctx context.Context, in *GetAllTagsRequest, opts ...grpc.CallOption) (*GetAllTagsResponse, error)
+	GetPopular(ctx context.Context, in *GetPopularTagsRequest, opts ...grpc.CallOption) (*GetPopularTagsResponse, error)
+}
+
+type tagServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTagServiceClient(cc grpc.ClientConnInterface) TagServiceClient {
+	return &tagServiceClient{cc}
+}
+
+func (c *tagServiceClient) SetPostTags(ctx context.Context, in *SetPostTagsRequest, opts ...grpc.CallOption) (*PostTags, error) {
+	out := new(PostTags)
+	err := c.cc.Invoke(ctx, "/genproto.TagService/SetPostTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) GetPostTags(ctx context.Context, in *GetPostTagsRequest, opts ...grpc.CallOption) (*PostTags, error) {
+	out := new(PostTags)
+	err := c.cc.Invoke(ctx, "/genproto.TagService/GetPostTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) GetAll(ctx context.Context, in *GetAllTagsRequest, opts ...grpc.CallOption) (*GetAllTagsResponse, error) {
+	out := new(GetAllTagsResponse)
+	err := c.cc.Invoke(ctx, "/genproto.TagService/GetAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) GetPopular(ctx context.Context, in *GetPopularTagsRequest, opts ...grpc.CallOption) (*GetPopularTagsResponse, error) {
+	out := new(GetPopularTagsResponse)
+	err := c.cc.Invoke(ctx, "/genproto.TagService/GetPopular", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TagServiceServer is the server API for TagService service.
+// All implementations must embed UnimplementedTagServiceServer
+// for forward compatibility
+type TagServiceServer interface {
+	SetPostTags(context.Context, *SetPostTagsRequest) (*PostTags, error)
+	GetPostTags(context.Context, *GetPostTagsRequest) (*PostTags, error)
+	GetAll(context.Context, *GetAllTagsRequest) (*GetAllTagsResponse, error)
+	GetPopular(context.Context, *GetPopularTagsRequest) (*GetPopularTagsResponse, error)
+	mustEmbedUnimplementedTagServiceServer()
+}
+
+// UnimplementedTagServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTagServiceServer struct {
+}
+
+func (UnimplementedTagServiceServer) SetPostTags(context.Context, *SetPostTagsRequest) (*PostTags, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPostTags not implemented")
+}
+func (UnimplementedTagServiceServer) GetPostTags(context.Context, *GetPostTagsRequest) (*PostTags, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostTags not implemented")
+}
+func (UnimplementedTagServiceServer) GetAll(context.Context, *GetAllTagsRequest) (*GetAllTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (UnimplementedTagServiceServer) GetPopular(context.Context, *GetPopularTagsRequest) (*GetPopularTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPopular not implemented")
+}
+func (UnimplementedTagServiceServer) mustEmbedUnimplementedTagServiceServer() {}
+
+// UnsafeTagServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TagServiceServer will
+// result in compilation errors.
+type UnsafeTagServiceServer interface {
+	mustEmbedUnimplementedTagServiceServer()
+}
+
+func RegisterTagServiceServer(s grpc.ServiceRegistrar, srv TagServiceServer) {
+	s.RegisterService(&TagService_ServiceDesc, srv)
+}
+
+func _TagService_SetPostTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPostTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).SetPostTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.TagService/SetPostTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).SetPostTags(ctx, req.(*SetPostTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_GetPostTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).GetPostTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.TagService/GetPostTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).GetPostTags(ctx, req.(*GetPostTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).GetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.TagService/GetAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).GetAll(ctx, req.(*GetAllTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_GetPopular_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPopularTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).GetPopular(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.TagService/GetPopular",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).GetPopular(ctx, req.(*GetPopularTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TagService_ServiceDesc is the grpc.ServiceDesc for TagService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TagService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "genproto.TagService",
+	HandlerType: (*TagServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetPostTags",
+			Handler:    _TagService_SetPostTags_Handler,
+		},
+		{
+			MethodName: "GetPostTags",
+			Handler:    _TagService_GetPostTags_Handler,
+		},
+		{
+			MethodName: "GetAll",
+			Handler:    _TagService_GetAll_Handler,
+		},
+		{
+			MethodName: "GetPopular",
+			Handler:    _TagService_GetPopular_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "tag_service.proto",
+}
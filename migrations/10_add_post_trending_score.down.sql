@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS posts_trending_score_idx;
+DROP INDEX IF EXISTS posts_comments_count_idx;
+DROP INDEX IF EXISTS posts_likes_count_idx;
+DROP INDEX IF EXISTS posts_views_count_idx;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS trending_score;
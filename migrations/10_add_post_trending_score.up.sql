@@ -0,0 +1,6 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS "trending_score" DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS posts_views_count_idx ON posts(views_count, id) WHERE status='published';
+CREATE INDEX IF NOT EXISTS posts_likes_count_idx ON posts(likes_count, id) WHERE status='published';
+CREATE INDEX IF NOT EXISTS posts_comments_count_idx ON posts(comments_count, id) WHERE status='published';
+CREATE INDEX IF NOT EXISTS posts_trending_score_idx ON posts(trending_score, id) WHERE status='published';
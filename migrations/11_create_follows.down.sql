@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS posts_category_id_created_at_idx;
+DROP INDEX IF EXISTS posts_user_id_created_at_idx;
+
+DROP TABLE IF EXISTS feed_seen_posts;
+DROP TABLE IF EXISTS category_subscriptions;
+DROP TABLE IF EXISTS follows;
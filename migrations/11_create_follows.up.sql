@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS "follows"(
+    "follower_id" INTEGER NOT NULL,
+    "followee_id" INTEGER NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY(follower_id, followee_id),
+    CHECK(follower_id<>followee_id)
+);
+CREATE INDEX IF NOT EXISTS follows_followee_id_idx ON follows(followee_id);
+
+CREATE TABLE IF NOT EXISTS "category_subscriptions"(
+    "user_id" INTEGER NOT NULL,
+    "category_id" INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY(user_id, category_id)
+);
+CREATE INDEX IF NOT EXISTS category_subscriptions_category_id_idx ON category_subscriptions(category_id);
+
+CREATE TABLE IF NOT EXISTS "feed_seen_posts"(
+    "user_id" INTEGER NOT NULL,
+    "post_id" INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    "seen_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY(user_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS posts_user_id_created_at_idx ON posts(user_id, created_at, id) WHERE status='published';
+CREATE INDEX IF NOT EXISTS posts_category_id_created_at_idx ON posts(category_id, created_at, id) WHERE status='published';
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS "bookmarks"(
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INTEGER NOT NULL,
+    "post_id" INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, post_id)
+);
+CREATE INDEX IF NOT EXISTS bookmarks_user_id_created_at_idx ON bookmarks(user_id, created_at, id);
+CREATE INDEX IF NOT EXISTS bookmarks_post_id_idx ON bookmarks(post_id);
+
+CREATE TABLE IF NOT EXISTS "reading_lists"(
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INTEGER NOT NULL,
+    "name" VARCHAR(100) NOT NULL,
+    "is_public" BOOLEAN NOT NULL DEFAULT false,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP WITH TIME ZONE,
+    UNIQUE(user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS "reading_list_posts"(
+    "list_id" INTEGER NOT NULL REFERENCES reading_lists(id) ON DELETE CASCADE,
+    "post_id" INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    "position" INTEGER NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY(list_id, post_id)
+);
+CREATE INDEX IF NOT EXISTS reading_list_posts_post_id_idx ON reading_list_posts(post_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS posts_deleted_at_idx;
+DROP INDEX IF EXISTS posts_user_id_deleted_at_idx;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS "deleted_at";
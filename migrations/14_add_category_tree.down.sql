@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS categories_parent_id_idx;
+DROP INDEX IF EXISTS categories_slug_idx;
+
+ALTER TABLE categories DROP COLUMN IF EXISTS "slug";
+ALTER TABLE categories DROP COLUMN IF EXISTS "parent_id";
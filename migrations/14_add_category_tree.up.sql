@@ -0,0 +1,36 @@
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS "parent_id" INTEGER REFERENCES categories(id);
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS "slug" VARCHAR(100);
+
+-- Created before the backfill to look the slugs up quickly, the NULLs of
+-- the rows yet to be filled don't collide.
+CREATE UNIQUE INDEX IF NOT EXISTS categories_slug_idx ON categories(slug);
+
+-- Existing categories get their slugs from the titles, a taken slug gets the
+-- first free numeric suffix, like new categories do.
+DO $$
+DECLARE
+    r RECORD;
+    candidate TEXT;
+    n INTEGER;
+BEGIN
+    FOR r IN
+        SELECT
+            id,
+            COALESCE(NULLIF(trim(BOTH '-' FROM left(lower(regexp_replace(title, '[^[:alnum:]]+', '-', 'g')), 80)), ''), 'category') AS base
+        FROM categories
+        WHERE slug IS NULL
+        ORDER BY id
+    LOOP
+        candidate := r.base;
+        n := 2;
+        WHILE EXISTS (SELECT 1 FROM categories WHERE slug=candidate) LOOP
+            candidate := rtrim(left(r.base, 80 - length(n::TEXT) - 1), '-') || '-' || n;
+            n := n + 1;
+        END LOOP;
+        UPDATE categories SET slug=candidate WHERE id=r.id;
+    END LOOP;
+END $$;
+
+ALTER TABLE categories ALTER COLUMN "slug" SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS categories_parent_id_idx ON categories(parent_id);
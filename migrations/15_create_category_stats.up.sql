@@ -0,0 +1,24 @@
+-- The statistics are refreshed periodically, they count the visible posts
+-- of the category itself, not of its descendants.
+CREATE MATERIALIZED VIEW IF NOT EXISTS category_stats AS
+SELECT
+    c.id AS category_id,
+    count(p.id) AS posts_count,
+    max(p.published_at) AS last_post_at,
+    count(p.id) FILTER (WHERE p.published_at >= now() - INTERVAL '7 days') AS weekly_posts,
+    COALESCE(s.followers_count, 0) AS followers_count,
+    COALESCE(s.weekly_followers, 0) AS weekly_followers
+FROM categories c
+LEFT JOIN posts p ON p.category_id=c.id AND p.status='published' AND p.deleted_at IS NULL
+LEFT JOIN (
+    SELECT
+        category_id,
+        count(1) AS followers_count,
+        count(1) FILTER (WHERE created_at >= now() - INTERVAL '7 days') AS weekly_followers
+    FROM category_subscriptions
+    GROUP BY category_id
+) s ON s.category_id=c.id
+GROUP BY c.id, s.followers_count, s.weekly_followers;
+
+-- REFRESH ... CONCURRENTLY needs a unique index.
+CREATE UNIQUE INDEX IF NOT EXISTS category_stats_category_id_idx ON category_stats(category_id);
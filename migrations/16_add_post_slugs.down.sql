@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS post_slugs;
+
+DROP INDEX IF EXISTS posts_slug_idx;
+DROP INDEX IF EXISTS posts_public_id_idx;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS "slug";
+ALTER TABLE posts DROP COLUMN IF EXISTS "public_id";
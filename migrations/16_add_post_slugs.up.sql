@@ -0,0 +1,71 @@
+-- gen_random_uuid is built in since Postgres 13, pgcrypto provides it
+-- on the older versions.
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS "public_id" UUID NOT NULL DEFAULT gen_random_uuid();
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS "slug" VARCHAR(100);
+
+-- The suffix search below looks the slugs up through the index, the posts
+-- without a slug yet don't collide as NULLs are distinct.
+CREATE UNIQUE INDEX IF NOT EXISTS posts_slug_idx ON posts(slug);
+
+-- truncate_slug cuts a slug to max_bytes like pkg/slug does, the limit
+-- is in bytes, not in characters.
+CREATE FUNCTION pg_temp.truncate_slug(s TEXT, max_bytes INTEGER) RETURNS TEXT AS $$
+BEGIN
+    WHILE octet_length(s) > max_bytes LOOP
+        s := left(s, -1);
+    END LOOP;
+    RETURN rtrim(s, '-');
+END
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+CREATE TEMPORARY TABLE post_slug_bases AS
+SELECT id, base, row_number() OVER (PARTITION BY base ORDER BY id) AS n
+FROM (
+    SELECT
+        id,
+        COALESCE(NULLIF(pg_temp.truncate_slug(trim(BOTH '-' FROM lower(regexp_replace(title, '[^[:alnum:]]+', '-', 'g'))), 80), ''), 'post') AS base
+    FROM posts
+    WHERE slug IS NULL
+) bases;
+
+-- The oldest post of every base gets the plain slug, so the suffixes
+-- given below never take one of them.
+UPDATE posts p SET slug=b.base
+FROM post_slug_bases b
+WHERE b.id=p.id AND b.n=1;
+
+-- The rest get the first free "-N" suffix, like the posts created later.
+DO $$
+DECLARE
+    r RECORD;
+    suffix INTEGER;
+    candidate TEXT;
+BEGIN
+    FOR r IN SELECT id, base FROM post_slug_bases WHERE n>1 ORDER BY id LOOP
+        suffix := 1;
+        LOOP
+            suffix := suffix + 1;
+            candidate := pg_temp.truncate_slug(r.base, 80 - octet_length('-' || suffix)) || '-' || suffix;
+            EXIT WHEN NOT EXISTS (SELECT 1 FROM posts WHERE slug=candidate);
+        END LOOP;
+        UPDATE posts SET slug=candidate WHERE id=r.id;
+    END LOOP;
+END
+$$;
+
+DROP TABLE post_slug_bases;
+
+ALTER TABLE posts ALTER COLUMN "slug" SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS posts_public_id_idx ON posts(public_id);
+
+-- The previous slugs of the posts, kept to redirect the old links.
+CREATE TABLE IF NOT EXISTS "post_slugs" (
+    "slug" VARCHAR(100) PRIMARY KEY,
+    "post_id" INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS post_slugs_post_id_idx ON post_slugs(post_id);
@@ -0,0 +1,10 @@
+ALTER TABLE "posts"
+    ADD COLUMN IF NOT EXISTS "status" VARCHAR(20) NOT NULL DEFAULT 'published'
+        CHECK ("status" IN ('draft', 'published', 'archived')),
+    ADD COLUMN IF NOT EXISTS "published_at" TIMESTAMP WITH TIME ZONE;
+
+UPDATE posts SET published_at=created_at WHERE status='published';
+
+ALTER TABLE "posts" ALTER COLUMN "status" SET DEFAULT 'draft';
+
+CREATE INDEX IF NOT EXISTS posts_status_created_at_idx ON posts(status, created_at);
@@ -0,0 +1,4 @@
+ALTER TABLE "posts" ADD COLUMN IF NOT EXISTS "publish_at" TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS posts_publish_at_idx ON posts(publish_at)
+    WHERE status='draft' AND publish_at IS NOT NULL;
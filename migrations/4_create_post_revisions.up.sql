@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "post_revisions"(
+    "id" SERIAL PRIMARY KEY,
+    "post_id" INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    "revision" INTEGER NOT NULL,
+    "title" VARCHAR NOT NULL,
+    "description" TEXT NOT NULL,
+    "user_id" INTEGER NOT NULL,
+    "restored_from" INTEGER,
+    "created_at" TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(post_id, revision)
+);
+
+INSERT INTO post_revisions(post_id, revision, title, description, user_id, created_at)
+SELECT id, 1, title, description, user_id, COALESCE(updated_at, created_at) FROM posts
+ON CONFLICT DO NOTHING;
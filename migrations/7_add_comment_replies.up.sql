@@ -0,0 +1,6 @@
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS "parent_id" INTEGER REFERENCES comments(id);
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS "depth" SMALLINT NOT NULL DEFAULT 0;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS "replies_count" INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS "deleted_at" TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS comments_parent_id_created_at_idx ON comments(parent_id, created_at, id);
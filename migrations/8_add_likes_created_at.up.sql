@@ -0,0 +1,4 @@
+ALTER TABLE likes ADD COLUMN IF NOT EXISTS "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS likes_post_id_created_at_idx ON likes(post_id, created_at);
+CREATE INDEX IF NOT EXISTS likes_user_id_created_at_idx ON likes(user_id, created_at);
@@ -0,0 +1,3 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS comments_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS dislikes_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS likes_count;
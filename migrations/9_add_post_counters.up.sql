@@ -0,0 +1,8 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS "likes_count" INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS "dislikes_count" INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS "comments_count" INTEGER NOT NULL DEFAULT 0;
+
+UPDATE posts p SET
+    likes_count=(SELECT count(1) FROM likes l WHERE l.post_id=p.id AND l.status),
+    dislikes_count=(SELECT count(1) FROM likes l WHERE l.post_id=p.id AND NOT l.status),
+    comments_count=(SELECT count(1) FROM comments c WHERE c.post_id=p.id AND c.deleted_at IS NULL);
@@ -0,0 +1,105 @@
+// Package auth authenticates incoming gRPC calls with the access token
+// verified by the user service and keeps the result in the context.
+package auth
+
+import (
+	"context"
+	"strings"
+
+	pbu "github.com/mirasildev/medium_post_service/genproto/user_service"
+	grpcPkg "github.com/mirasildev/medium_post_service/pkg/grpc_client"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "bearer "
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx which carries the payload of the caller.
+func NewContext(ctx context.Context, payload *pbu.AuthPayload) context.Context {
+	return context.WithValue(ctx, contextKey{}, payload)
+}
+
+// FromContext returns the payload of the caller, ok is false for
+// anonymous calls.
+func FromContext(ctx context.Context) (payload *pbu.AuthPayload, ok bool) {
+	payload, ok = ctx.Value(contextKey{}).(*pbu.AuthPayload)
+	return payload, ok && payload != nil
+}
+
+// NewInterceptor verifies the bearer token of every call through
+// AuthService.VerifyToken and authorizes the call by policies. A call
+// with a token is authenticated even when the RPC is public.
+func NewInterceptor(grpcClient grpcPkg.GrpcClientI, logger *logrus.Logger, policies Policies) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		token, err := bearerToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if token == "" {
+			if err := policies.Authorize(info.FullMethod, nil); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}
+
+		resource, action := splitMethod(info.FullMethod)
+		payload, err := grpcClient.AuthService().VerifyToken(ctx, &pbu.VerifyTokenRequest{
+			AccessToken: token,
+			Resource:    resource,
+			Action:      action,
+		})
+		if err != nil {
+			switch status.Code(err) {
+			case codes.Unauthenticated, codes.InvalidArgument, codes.NotFound, codes.PermissionDenied:
+				return nil, status.Errorf(codes.Unauthenticated, "invalid access token")
+			}
+			logger.WithError(err).Error("failed to verify access token")
+			return nil, status.Errorf(codes.Unavailable, "failed to verify access token")
+		}
+
+		if err := policies.Authorize(info.FullMethod, payload); err != nil {
+			return nil, err
+		}
+
+		return handler(NewContext(ctx, payload), req)
+	}
+}
+
+func bearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	value := values[0]
+	if len(value) < len(bearerPrefix) || !strings.EqualFold(value[:len(bearerPrefix)], bearerPrefix) ||
+		strings.TrimSpace(value[len(bearerPrefix):]) == "" {
+		return "", status.Errorf(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+
+	return strings.TrimSpace(value[len(bearerPrefix):]), nil
+}
+
+// splitMethod turns "/genproto.PostService/Create" into
+// ("genproto.PostService", "Create").
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "", fullMethod
+}
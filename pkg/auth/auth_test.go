@@ -0,0 +1,135 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	pbu "github.com/mirasildev/medium_post_service/genproto/user_service"
+	"github.com/mirasildev/medium_post_service/pkg/auth"
+	"github.com/mirasildev/medium_post_service/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const validToken = "valid-token"
+
+type fakeAuthService struct {
+	pbu.AuthServiceClient
+	lastRequest *pbu.VerifyTokenRequest
+	err         error
+}
+
+func (f *fakeAuthService) VerifyToken(ctx context.Context, in *pbu.VerifyTokenRequest, opts ...grpc.CallOption) (*pbu.AuthPayload, error) {
+	f.lastRequest = in
+	if f.err != nil {
+		return nil, f.err
+	}
+	if in.AccessToken != validToken {
+		return nil, status.Error(codes.Unauthenticated, "token is expired")
+	}
+	return &pbu.AuthPayload{UserId: 7, UserType: "user"}, nil
+}
+
+type fakeGrpcClient struct {
+	authService *fakeAuthService
+}
+
+func (f *fakeGrpcClient) UserService() pbu.UserServiceClient {
+	return nil
+}
+
+func (f *fakeGrpcClient) AuthService() pbu.AuthServiceClient {
+	return f.authService
+}
+
+func call(interceptor grpc.UnaryServerInterceptor, method, authorization string) (*pbu.AuthPayload, error) {
+	ctx := context.Background()
+	if authorization != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", authorization))
+	}
+
+	var payload *pbu.AuthPayload
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		payload, _ = auth.FromContext(ctx)
+		return nil, nil
+	})
+	return payload, err
+}
+
+func TestInterceptor(t *testing.T) {
+	const (
+		public  = "/genproto.PostService/Get"
+		private = "/genproto.PostService/Create"
+	)
+
+	tests := []struct {
+		name          string
+		method        string
+		authorization string
+		wantCode      codes.Code
+		wantUserID    int64
+	}{
+		{name: "public without token", method: public, wantCode: codes.OK},
+		{name: "public with token", method: public, authorization: "Bearer " + validToken, wantCode: codes.OK, wantUserID: 7},
+		{name: "private without token", method: private, wantCode: codes.Unauthenticated},
+		{name: "private with token", method: private, authorization: "bearer " + validToken, wantCode: codes.OK, wantUserID: 7},
+		{name: "invalid token", method: private, authorization: "Bearer expired", wantCode: codes.Unauthenticated},
+		{name: "invalid token on public method", method: public, authorization: "Bearer expired", wantCode: codes.Unauthenticated},
+		{name: "not a bearer token", method: private, authorization: "Basic dXNlcjpwYXNz", wantCode: codes.Unauthenticated},
+		{name: "empty bearer token", method: public, authorization: "Bearer ", wantCode: codes.Unauthenticated},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			policies := auth.Policies{
+				public:  {Public: true},
+				private: {},
+			}
+			interceptor := auth.NewInterceptor(&fakeGrpcClient{authService: &fakeAuthService{}}, logger.New(), policies)
+
+			payload, err := call(interceptor, tc.method, tc.authorization)
+			require.Equal(t, tc.wantCode, status.Code(err))
+			if tc.wantUserID != 0 {
+				require.NotNil(t, payload)
+				require.Equal(t, tc.wantUserID, payload.UserId)
+			} else {
+				require.Nil(t, payload)
+			}
+		})
+	}
+}
+
+func TestInterceptorSendsResourceAndAction(t *testing.T) {
+	authService := &fakeAuthService{}
+	interceptor := auth.NewInterceptor(&fakeGrpcClient{authService: authService}, logger.New(), auth.Policies{})
+
+	_, err := call(interceptor, "/genproto.PostService/Update", "Bearer "+validToken)
+	require.NoError(t, err)
+	require.Equal(t, "genproto.PostService", authService.lastRequest.Resource)
+	require.Equal(t, "Update", authService.lastRequest.Action)
+}
+
+func TestInterceptorChecksRoles(t *testing.T) {
+	const method = "/genproto.CategoryService/Create"
+	policies := auth.Policies{
+		method: {Roles: []string{auth.UserTypeAdmin}},
+	}
+	interceptor := auth.NewInterceptor(&fakeGrpcClient{authService: &fakeAuthService{}}, logger.New(), policies)
+
+	_, err := call(interceptor, method, "Bearer "+validToken)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = call(interceptor, method, "")
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestInterceptorUserServiceUnavailable(t *testing.T) {
+	authService := &fakeAuthService{err: status.Error(codes.Unavailable, "connection refused")}
+	interceptor := auth.NewInterceptor(&fakeGrpcClient{authService: authService}, logger.New(), auth.Policies{})
+
+	_, err := call(interceptor, "/genproto.PostService/Update", "Bearer "+validToken)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	pbu "github.com/mirasildev/medium_post_service/genproto/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// User types issued by the user service.
+const (
+	UserTypeUser      = "user"
+	UserTypeModerator = "moderator"
+	UserTypeAdmin     = "admin"
+)
+
+// Policy is the access rule of one RPC.
+type Policy struct {
+	// Public lets anonymous callers in.
+	Public bool
+	// Roles limits the RPC to these user types, any authenticated caller
+	// is allowed when it is empty. A caller of another type is still let
+	// in when the user service granted the permission for the RPC.
+	Roles []string
+}
+
+// Policies maps full method names to their rules. RPCs which are not in
+// the map require an authenticated caller.
+type Policies map[string]Policy
+
+// Authorize checks whether the caller may call method, payload is nil for
+// anonymous callers.
+func (p Policies) Authorize(method string, payload *pbu.AuthPayload) error {
+	policy := p[method]
+
+	if payload == nil {
+		if policy.Public {
+			return nil
+		}
+		return status.Errorf(codes.Unauthenticated, "access token is required")
+	}
+
+	if len(policy.Roles) == 0 || HasRole(payload, policy.Roles...) || payload.HasPermission {
+		return nil
+	}
+
+	return status.Errorf(codes.PermissionDenied, "%s is not allowed for %s users", method, payload.UserType)
+}
+
+// HasRole reports whether the caller is one of the user types.
+func HasRole(payload *pbu.AuthPayload, roles ...string) bool {
+	if payload == nil {
+		return false
+	}
+
+	for _, role := range roles {
+		if payload.UserType == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package diff_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mirasildev/medium_post_service/pkg/diff"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []diff.Line
+	}{
+		{
+			name: "equal",
+			a:    "a\nb",
+			b:    "a\nb",
+			want: []diff.Line{{diff.Equal, "a"}, {diff.Equal, "b"}},
+		},
+		{
+			name: "empty to text",
+			a:    "",
+			b:    "a\nb",
+			want: []diff.Line{{diff.Insert, "a"}, {diff.Insert, "b"}},
+		},
+		{
+			name: "text to empty",
+			a:    "a\nb\n",
+			b:    "",
+			want: []diff.Line{{diff.Delete, "a"}, {diff.Delete, "b"}},
+		},
+		{
+			name: "changed line in the middle",
+			a:    "a\nb\nc",
+			b:    "a\nx\nc",
+			want: []diff.Line{{diff.Equal, "a"}, {diff.Delete, "b"}, {diff.Insert, "x"}, {diff.Equal, "c"}},
+		},
+		{
+			name: "inserted and removed lines",
+			a:    "a\nb\nc\nd",
+			b:    "b\nc\ne\nd",
+			want: []diff.Line{{diff.Delete, "a"}, {diff.Equal, "b"}, {diff.Equal, "c"}, {diff.Insert, "e"}, {diff.Equal, "d"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lines, err := diff.Lines(tc.a, tc.b)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, lines)
+		})
+	}
+}
+
+func TestLinesTooLarge(t *testing.T) {
+	large := strings.Repeat("line\n", diff.MaxLines+1)
+
+	_, err := diff.Lines(large, "a")
+	require.ErrorIs(t, err, diff.ErrTooLarge)
+
+	_, err = diff.Lines("a", large)
+	require.ErrorIs(t, err, diff.ErrTooLarge)
+
+	_, err = diff.Lines(strings.Repeat("line\n", diff.MaxLines), "a")
+	require.NoError(t, err)
+}
@@ -11,6 +11,7 @@ import (
 
 type GrpcClientI interface {
 	UserService() pbu.UserServiceClient
+	AuthService() pbu.AuthServiceClient
 }
 
 type GrpcClient struct {
@@ -32,6 +33,7 @@ func New(cfg config.Config) (GrpcClientI, error) {
 		cfg: cfg,
 		connections: map[string]interface{}{
 			"user_service": pbu.NewUserServiceClient(connUserService),
+			"auth_service": pbu.NewAuthServiceClient(connUserService),
 		},
 	}, nil
 	
@@ -39,4 +41,8 @@ func New(cfg config.Config) (GrpcClientI, error) {
 
 func (g *GrpcClient) UserService() pbu.UserServiceClient {
 	return g.connections["user_service"].(pbu.UserServiceClient)
+}
+
+func (g *GrpcClient) AuthService() pbu.AuthServiceClient {
+	return g.connections["auth_service"].(pbu.AuthServiceClient)
 }
\ No newline at end of file
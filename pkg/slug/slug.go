@@ -0,0 +1,57 @@
+// Package slug turns titles into readable URL path segments.
+package slug
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength is the longest slug in bytes, suffixes included.
+const MaxLength = 80
+
+// Make lowercases s, drops the accents of its letters and joins the runs of
+// letters and digits with dashes, e.g. "Go & Café!" becomes "go-cafe". It
+// returns an empty string when s has no letters or digits.
+func Make(s string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Combining marks left from decomposed letters.
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			dash = false
+			sb.WriteRune(unicode.ToLower(r))
+		default:
+			dash = true
+		}
+	}
+
+	return truncate(sb.String(), MaxLength)
+}
+
+// WithSuffix returns the n-th variant of a taken slug, e.g. "go-2". The
+// slug is shortened to keep the result within MaxLength.
+func WithSuffix(slug string, n int) string {
+	suffix := "-" + strconv.Itoa(n)
+	return truncate(slug, MaxLength-len(suffix)) + suffix
+}
+
+// truncate cuts s to at most n bytes at a rune boundary, without leaving
+// a dash at the end.
+func truncate(s string, n int) string {
+	if len(s) > n {
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		s = s[:n]
+	}
+	return strings.TrimRight(s, "-")
+}
@@ -0,0 +1,48 @@
+package slug_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mirasildev/medium_post_service/pkg/slug"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{name: "words", title: "Hello World", want: "hello-world"},
+		{name: "punctuation", title: "  Go & Café! ", want: "go-cafe"},
+		{name: "digits", title: "Top 10 tips", want: "top-10-tips"},
+		{name: "non latin", title: "Привет, мир", want: "привет-мир"},
+		{name: "no letters", title: "?!", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, slug.Make(tt.title))
+		})
+	}
+}
+
+func TestMakeTruncates(t *testing.T) {
+	s := slug.Make(strings.Repeat("ab ", 40))
+	require.LessOrEqual(t, len(s), slug.MaxLength)
+	require.False(t, strings.HasSuffix(s, "-"))
+
+	s = slug.Make(strings.Repeat("ж", 100))
+	require.LessOrEqual(t, len(s), slug.MaxLength)
+	require.True(t, strings.HasPrefix(strings.Repeat("ж", 100), s))
+}
+
+func TestWithSuffix(t *testing.T) {
+	require.Equal(t, "go-2", slug.WithSuffix("go", 2))
+
+	long := slug.Make(strings.Repeat("a", 100))
+	s := slug.WithSuffix(long, 12)
+	require.Len(t, s, slug.MaxLength)
+	require.True(t, strings.HasSuffix(s, "-12"))
+}
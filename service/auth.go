@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mirasildev/medium_post_service/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// actingUserID returns the id of the authenticated caller.
+func actingUserID(ctx context.Context) (int64, error) {
+	payload, ok := auth.FromContext(ctx)
+	if !ok {
+		return 0, status.Errorf(codes.Unauthenticated, "access token is required")
+	}
+	return payload.UserId, nil
+}
+
+// isActingUser reports whether the caller is authenticated as userID.
+func isActingUser(ctx context.Context, userID int64) bool {
+	payload, ok := auth.FromContext(ctx)
+	return ok && userID != 0 && payload.UserId == userID
+}
+
+// canModerate reports whether the caller may remove other users' content.
+func canModerate(ctx context.Context) bool {
+	payload, _ := auth.FromContext(ctx)
+	return auth.HasRole(payload, auth.UserTypeModerator, auth.UserTypeAdmin)
+}
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	pb "github.com/mirasildev/medium_post_service/genproto/post_service"
+	"github.com/mirasildev/medium_post_service/storage"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	defaultBookmarksLimit = 20
+	maxBookmarksLimit     = 100
+	maxReadingListName    = 100
+)
+
+type BookmarkService struct {
+	pb.UnimplementedBookmarkServiceServer
+	storage storage.StorageI
+	logger  *logrus.Logger
+}
+
+func NewBookmarkService(strg storage.StorageI, logger *logrus.Logger) *BookmarkService {
+	return &BookmarkService{
+		storage: strg,
+		logger:  logger,
+	}
+}
+
+func (s *BookmarkService) Add(ctx context.Context, req *pb.BookmarkRequest) (*pb.Bookmark, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmark, err := s.storage.Bookmark().Add(userID, req.PostId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "post is not found")
+		}
+		s.logger.WithError(err).Error("failed to add bookmark")
+		return nil, status.Errorf(codes.Internal, "failed to add bookmark: %v", err)
+	}
+
+	return parseBookmarkModel(bookmark), nil
+}
+
+func (s *BookmarkService) Remove(ctx context.Context, req *pb.BookmarkRequest) (*emptypb.Empty, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.Bookmark().Remove(userID, req.PostId); err != nil {
+		s.logger.WithError(err).Error("failed to remove bookmark")
+		return nil, status.Errorf(codes.Internal, "failed to remove bookmark: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *BookmarkService) List(ctx context.Context, req *pb.ListBookmarksRequest) (*pb.ListBookmarksResponse, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultBookmarksLimit
+	}
+	if limit > maxBookmarksLimit {
+		limit = maxBookmarksLimit
+	}
+
+	res, err := s.storage.Bookmark().GetAll(&repo.GetBookmarksParams{
+		UserID: userID,
+		Limit:  limit,
+		After:  after,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to list bookmarks")
+		return nil, status.Errorf(codes.Internal, "failed to list bookmarks: %v", err)
+	}
+
+	response := pb.ListBookmarksResponse{
+		Bookmarks:     make([]*pb.Bookmark, 0, len(res.Bookmarks)),
+		Count:         res.Count,
+		NextPageToken: encodePageToken(res.Next),
+	}
+	for _, bookmark := range res.Bookmarks {
+		response.Bookmarks = append(response.Bookmarks, parseBookmarkModel(bookmark))
+	}
+
+	return &response, nil
+}
+
+func (s *BookmarkService) IsBookmarked(ctx context.Context, req *pb.IsBookmarkedRequest) (*pb.IsBookmarkedResponse, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarked, err := s.storage.Bookmark().GetBookmarked(userID, req.PostIds)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get bookmarked posts")
+		return nil, status.Errorf(codes.Internal, "failed to get bookmarked posts: %v", err)
+	}
+
+	response := pb.IsBookmarkedResponse{
+		BookmarkedPostIds: make([]int64, 0, len(bookmarked)),
+	}
+	// Keeps the order of the request.
+	for _, id := range req.PostIds {
+		if bookmarked[id] {
+			response.BookmarkedPostIds = append(response.BookmarkedPostIds, id)
+			delete(bookmarked, id)
+		}
+	}
+
+	return &response, nil
+}
+
+func parseBookmarkModel(b *repo.Bookmark) *pb.Bookmark {
+	bookmark := pb.Bookmark{
+		Id:        b.ID,
+		PostId:    b.PostID,
+		CreatedAt: b.CreatedAt.Format(time.RFC3339),
+	}
+	if b.Post != nil {
+		bookmark.Post = parsePostModel(b.Post)
+	}
+
+	return &bookmark
+}
+
+func (s *BookmarkService) CreateReadingList(ctx context.Context, req *pb.CreateReadingListRequest) (*pb.ReadingList, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := normalizeReadingListName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.storage.ReadingList().Create(&repo.ReadingList{
+		UserID:   userID,
+		Name:     name,
+		IsPublic: req.IsPublic,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to create reading list")
+		return nil, readingListWriteError(err, "failed to create reading list")
+	}
+
+	return parseReadingListModel(list), nil
+}
+
+func (s *BookmarkService) UpdateReadingList(ctx context.Context, req *pb.UpdateReadingListRequest) (*pb.ReadingList, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := normalizeReadingListName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.storage.ReadingList().Update(&repo.ReadingList{
+		ID:       req.Id,
+		UserID:   userID,
+		Name:     name,
+		IsPublic: req.IsPublic,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to update reading list")
+		return nil, readingListWriteError(err, "failed to update reading list")
+	}
+
+	return parseReadingListModel(list), nil
+}
+
+func (s *BookmarkService) DeleteReadingList(ctx context.Context, req *pb.GetReadingListRequest) (*emptypb.Empty, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.ReadingList().Delete(req.Id, userID); err != nil {
+		s.logger.WithError(err).Error("failed to delete reading list")
+		return nil, readingListWriteError(err, "failed to delete reading list")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetReadingList returns a public list or a list of the caller with its
+// posts.
+func (s *BookmarkService) GetReadingList(ctx context.Context, req *pb.GetReadingListRequest) (*pb.ReadingListPosts, error) {
+	list, err := s.storage.ReadingList().Get(req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "reading list is not found")
+		}
+		s.logger.WithError(err).Error("failed to get reading list")
+		return nil, status.Errorf(codes.Internal, "failed to get reading list: %v", err)
+	}
+
+	// Private lists look missing to other users.
+	if !list.IsPublic && !isActingUser(ctx, list.UserID) {
+		return nil, status.Errorf(codes.NotFound, "reading list is not found")
+	}
+
+	return s.readingListPosts(list)
+}
+
+// ListReadingLists returns the lists of UserId, or of the caller when it
+// is 0. Only the public lists of other users are returned.
+func (s *BookmarkService) ListReadingLists(ctx context.Context, req *pb.ListReadingListsRequest) (*pb.ListReadingListsResponse, error) {
+	userID := req.UserId
+	if userID == 0 {
+		var err error
+		if userID, err = actingUserID(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	lists, err := s.storage.ReadingList().GetAll(userID, isActingUser(ctx, userID))
+	if err != nil {
+		s.logger.WithError(err).Error("failed to list reading lists")
+		return nil, status.Errorf(codes.Internal, "failed to list reading lists: %v", err)
+	}
+
+	response := pb.ListReadingListsResponse{
+		Lists: make([]*pb.ReadingList, 0, len(lists)),
+	}
+	for _, list := range lists {
+		response.Lists = append(response.Lists, parseReadingListModel(list))
+	}
+
+	return &response, nil
+}
+
+func (s *BookmarkService) AddToReadingList(ctx context.Context, req *pb.ReadingListPostRequest) (*emptypb.Empty, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.ReadingList().AddPost(req.ListId, userID, req.PostId); err != nil {
+		s.logger.WithError(err).Error("failed to add post to reading list")
+		return nil, readingListWriteError(err, "failed to add post to reading list")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *BookmarkService) RemoveFromReadingList(ctx context.Context, req *pb.ReadingListPostRequest) (*emptypb.Empty, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.ReadingList().RemovePost(req.ListId, userID, req.PostId); err != nil {
+		s.logger.WithError(err).Error("failed to remove post from reading list")
+		return nil, readingListWriteError(err, "failed to remove post from reading list")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *BookmarkService) ReorderReadingList(ctx context.Context, req *pb.ReorderReadingListRequest) (*pb.ReadingListPosts, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.ReadingList().Reorder(req.ListId, userID, req.PostIds); err != nil {
+		s.logger.WithError(err).Error("failed to reorder reading list")
+		return nil, readingListWriteError(err, "failed to reorder reading list")
+	}
+
+	list, err := s.storage.ReadingList().Get(req.ListId)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get reading list")
+		return nil, status.Errorf(codes.Internal, "failed to get reading list: %v", err)
+	}
+
+	return s.readingListPosts(list)
+}
+
+func (s *BookmarkService) readingListPosts(list *repo.ReadingList) (*pb.ReadingListPosts, error) {
+	posts, err := s.storage.ReadingList().GetPosts(list.ID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get posts of reading list")
+		return nil, status.Errorf(codes.Internal, "failed to get posts of reading list: %v", err)
+	}
+
+	response := pb.ReadingListPosts{
+		List:  parseReadingListModel(list),
+		Posts: make([]*pb.Post, 0, len(posts)),
+	}
+	for _, post := range posts {
+		response.Posts = append(response.Posts, parsePostModel(post))
+	}
+
+	return &response, nil
+}
+
+func normalizeReadingListName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", status.Errorf(codes.InvalidArgument, "name is required")
+	}
+	if utf8.RuneCountInString(name) > maxReadingListName {
+		return "", status.Errorf(codes.InvalidArgument, "name is longer than %d characters", maxReadingListName)
+	}
+	return name, nil
+}
+
+func readingListWriteError(err error, msg string) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Errorf(codes.NotFound, "reading list or post is not found")
+	case errors.Is(err, repo.ErrPermissionDenied):
+		return status.Errorf(codes.PermissionDenied, "you can't change other user's reading list")
+	case errors.Is(err, repo.ErrAlreadyExists):
+		return status.Errorf(codes.AlreadyExists, "you already have a reading list with this name")
+	case errors.Is(err, repo.ErrInvalidArgument):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
+
+func parseReadingListModel(l *repo.ReadingList) *pb.ReadingList {
+	list := pb.ReadingList{
+		Id:         l.ID,
+		UserId:     l.UserID,
+		Name:       l.Name,
+		IsPublic:   l.IsPublic,
+		PostsCount: l.PostsCount,
+		CreatedAt:  l.CreatedAt.Format(time.RFC3339),
+	}
+	if !l.UpdatedAt.IsZero() {
+		list.UpdatedAt = l.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return &list
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	pb "github.com/mirasildev/medium_post_service/genproto/post_service"
+	pbu "github.com/mirasildev/medium_post_service/genproto/user_service"
+	"github.com/mirasildev/medium_post_service/pkg/auth"
+	"github.com/mirasildev/medium_post_service/pkg/logger"
+	"github.com/mirasildev/medium_post_service/storage"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeReadingListRepo struct {
+	repo.ReadingListStorageI
+	lists map[int64]*repo.ReadingList
+}
+
+func (f *fakeReadingListRepo) Get(id int64) (*repo.ReadingList, error) {
+	l, ok := f.lists[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return l, nil
+}
+
+func (f *fakeReadingListRepo) GetPosts(id int64) ([]*repo.Post, error) {
+	return []*repo.Post{}, nil
+}
+
+type fakeBookmarkStorage struct {
+	storage.StorageI
+	lists *fakeReadingListRepo
+}
+
+func (f *fakeBookmarkStorage) ReadingList() repo.ReadingListStorageI {
+	return f.lists
+}
+
+func TestGetReadingListHidesPrivateLists(t *testing.T) {
+	s := NewBookmarkService(&fakeBookmarkStorage{
+		lists: &fakeReadingListRepo{lists: map[int64]*repo.ReadingList{
+			1: {ID: 1, UserID: 1, Name: "public", IsPublic: true},
+			2: {ID: 2, UserID: 1, Name: "private"},
+		}},
+	}, logger.New())
+	owner := auth.NewContext(context.Background(), &pbu.AuthPayload{UserId: 1})
+	other := auth.NewContext(context.Background(), &pbu.AuthPayload{UserId: 2})
+
+	_, err := s.GetReadingList(context.Background(), &pb.GetReadingListRequest{Id: 1})
+	require.NoError(t, err)
+
+	_, err = s.GetReadingList(other, &pb.GetReadingListRequest{Id: 2})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	list, err := s.GetReadingList(owner, &pb.GetReadingListRequest{Id: 2})
+	require.NoError(t, err)
+	require.Equal(t, "private", list.List.Name)
+
+	_, err = s.CreateReadingList(owner, &pb.CreateReadingListRequest{Name: "  "})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -30,20 +30,37 @@ func NewCategoryService(strg storage.StorageI, logger *logrus.Logger) *CategoryS
 
 func (s *CategoryService) Create(ctx context.Context, req *pb.Category) (*pb.Category, error) {
 	category, err := s.storage.Category().Create(&repo.Category{
-		Title: req.Title,
+		Title:    req.Title,
+		ParentID: req.ParentId,
 	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Internal server error: %v", err)
+		s.logger.WithError(err).Error("failed to create category")
+		return nil, categoryWriteError(err, "Internal server error")
 	}
 	return parseCategoryModel(category), nil
 }
 
 func parseCategoryModel(c *repo.Category) *pb.Category {
-	return &pb.Category{
+	category := pb.Category{
 		Id:        c.ID,
 		Title:     c.Title,
 		CreatedAt: c.CreatedAt.Format(time.RFC3339),
+		Slug:      c.Slug,
+		ParentId:  c.ParentID,
+	}
+	if c.Stats != nil {
+		category.Stats = &pb.CategoryStats{
+			PostsCount:      c.Stats.PostsCount,
+			FollowersCount:  c.Stats.FollowersCount,
+			WeeklyPosts:     c.Stats.WeeklyPosts,
+			WeeklyFollowers: c.Stats.WeeklyFollowers,
+		}
+		if !c.Stats.LastPostAt.IsZero() {
+			category.Stats.LastPostAt = c.Stats.LastPostAt.Format(time.RFC3339)
+		}
 	}
+
+	return &category
 }
 
 func (s *CategoryService) Get(ctx context.Context, req *pb.GetCategory) (*pb.Category, error) {
@@ -56,20 +73,88 @@ func (s *CategoryService) Get(ctx context.Context, req *pb.GetCategory) (*pb.Cat
 		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
 	}
 
-	return &pb.Category{
-		Id: resp.ID,
-		Title: resp.Title,
-		CreatedAt: resp.CreatedAt.Format(time.RFC3339),
-	}, nil
+	return parseCategoryModel(resp), nil
+}
+
+func (s *CategoryService) GetCategoryBySlug(ctx context.Context, req *pb.GetCategoryBySlugRequest) (*pb.Category, error) {
+	category, err := s.storage.Category().GetBySlug(req.Slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "category is not found")
+		}
+		s.logger.WithError(err).Error("failed to get category by slug")
+		return nil, status.Errorf(codes.Internal, "failed to get category: %v", err)
+	}
+
+	return parseCategoryModel(category), nil
+}
+
+// GetCategoryTree returns the categories nested under their parents,
+// starting from RootId or from every root category.
+func (s *CategoryService) GetCategoryTree(ctx context.Context, req *pb.GetCategoryTreeRequest) (*pb.CategoryTree, error) {
+	categories, err := s.storage.Category().GetTree(req.RootId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "category is not found")
+		}
+		s.logger.WithError(err).Error("failed to get category tree")
+		return nil, status.Errorf(codes.Internal, "failed to get category tree: %v", err)
+	}
+
+	return buildCategoryTree(categories), nil
+}
+
+// buildCategoryTree nests the categories, which come after their parents.
+// The ones whose parent isn't in the list are the roots.
+func buildCategoryTree(categories []*repo.Category) *pb.CategoryTree {
+	tree := pb.CategoryTree{
+		Roots: make([]*pb.CategoryNode, 0),
+	}
+
+	nodes := make(map[int64]*pb.CategoryNode, len(categories))
+	for _, c := range categories {
+		node := &pb.CategoryNode{
+			Category: parseCategoryModel(c),
+			Children: make([]*pb.CategoryNode, 0),
+		}
+		nodes[c.ID] = node
+
+		if parent, ok := nodes[c.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			tree.Roots = append(tree.Roots, node)
+		}
+	}
+
+	return &tree
+}
+
+// categorySorts maps the sorts of GetAll to the repo ones.
+var categorySorts = map[pb.CategorySort]string{
+	pb.CategorySort_CATEGORY_SORT_UNSPECIFIED:     "",
+	pb.CategorySort_CATEGORY_SORT_NEWEST:          repo.CategorySortNewest,
+	pb.CategorySort_CATEGORY_SORT_POPULAR:         repo.CategorySortPopular,
+	pb.CategorySort_CATEGORY_SORT_MOST_POSTS:      repo.CategorySortMostPosts,
+	pb.CategorySort_CATEGORY_SORT_RECENTLY_ACTIVE: repo.CategorySortRecentlyActive,
+	pb.CategorySort_CATEGORY_SORT_GROWING:         repo.CategorySortGrowing,
 }
 
 func (s *CategoryService) GetAll(ctx context.Context, req *pb.GetAllCategoriesRequest) (*pb.GetAllCategoriesResponse, error) {
+	sort, ok := categorySorts[req.Sort]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown sort %d", req.Sort)
+	}
+
 	res, err := s.storage.Category().GetAll(&repo.GetAllCategoriesParams{
 		Page:   req.Page,
 		Limit:  req.Limit,
 		Search: req.Search,
+		Sort:   sort,
 	})
 	if err != nil {
+		if errors.Is(err, repo.ErrInvalidArgument) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "Internal error: %v", err)
 	}
 
@@ -95,18 +180,57 @@ func (s *CategoryService) Update(ctx context.Context, req *pb.UpdateCategoryRequ
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Errorf(codes.NotFound, err.Error())
 		}
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "category with this title already exists")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update: %v", err)
 	}
 
 	return parseCategoryModel(category), nil
 }
 
-func (s *CategoryService) Delete(ctx context.Context, req *pb.GetCategory) (*emptypb.Empty, error) {
-	err := s.storage.Category().Delete(req.Id)
+func (s *CategoryService) MoveCategory(ctx context.Context, req *pb.MoveCategoryRequest) (*pb.Category, error) {
+	category, err := s.storage.Category().Move(req.Id, req.ParentId)
 	if err != nil {
-		s.logger.WithError(err).Error("failed to delete user")
-		return nil, status.Errorf(codes.Internal, "failed to delete: %v", err)
+		s.logger.WithError(err).Error("failed to move category")
+		return nil, categoryWriteError(err, "failed to move category")
+	}
+
+	return parseCategoryModel(category), nil
+}
+
+func (s *CategoryService) Delete(ctx context.Context, req *pb.DeleteCategoryRequest) (*emptypb.Empty, error) {
+	err := s.storage.Category().Delete(req.Id, req.TargetCategoryId)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to delete category")
+		return nil, categoryWriteError(err, "failed to delete")
 	}
 
 	return &emptypb.Empty{}, nil
 }
+
+// MergeCategories moves everything of the source categories to the
+// target one and deletes them.
+func (s *CategoryService) MergeCategories(ctx context.Context, req *pb.MergeCategoriesRequest) (*pb.Category, error) {
+	category, err := s.storage.Category().Merge(req.TargetCategoryId, req.SourceCategoryIds)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to merge categories")
+		return nil, categoryWriteError(err, "failed to merge categories")
+	}
+
+	return parseCategoryModel(category), nil
+}
+
+func categoryWriteError(err error, msg string) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Errorf(codes.NotFound, "category is not found")
+	case errors.Is(err, repo.ErrInvalidArgument):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, repo.ErrFailedPrecondition):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, repo.ErrAlreadyExists):
+		return status.Errorf(codes.AlreadyExists, "category with this title already exists")
+	}
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCategoryTree(t *testing.T) {
+	tree := buildCategoryTree([]*repo.Category{
+		{ID: 1, Title: "programming"},
+		{ID: 2, Title: "travel"},
+		{ID: 3, Title: "go", ParentID: 1},
+		{ID: 4, Title: "rust", ParentID: 1},
+		{ID: 5, Title: "generics", ParentID: 3},
+	})
+
+	require.Len(t, tree.Roots, 2)
+	programming := tree.Roots[0]
+	require.Equal(t, int64(1), programming.Category.Id)
+	require.Len(t, programming.Children, 2)
+	require.Equal(t, int64(3), programming.Children[0].Category.Id)
+	require.Equal(t, int64(5), programming.Children[0].Children[0].Category.Id)
+	require.Empty(t, tree.Roots[1].Children)
+
+	// A subtree starts from a category whose parent isn't listed.
+	subtree := buildCategoryTree([]*repo.Category{
+		{ID: 3, Title: "go", ParentID: 1},
+		{ID: 5, Title: "generics", ParentID: 3},
+	})
+	require.Len(t, subtree.Roots, 1)
+	require.Len(t, subtree.Roots[0].Children, 1)
+}
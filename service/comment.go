@@ -11,7 +11,6 @@ import (
 
 	pb "github.com/mirasildev/medium_post_service/genproto/post_service"
 	"github.com/mirasildev/medium_post_service/genproto/user_service"
-	grpcPkg "github.com/mirasildev/medium_post_service/pkg/grpc_client"
 	"github.com/mirasildev/medium_post_service/storage"
 	"github.com/mirasildev/medium_post_service/storage/repo"
 	"github.com/sirupsen/logrus"
@@ -20,33 +19,47 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+const defaultRepliesLimit = 20
+
 type CommentService struct {
 	pb.UnimplementedCommentServiceServer
-	storage    storage.StorageI
-	grpcClient grpcPkg.GrpcClientI
-	logger     *logrus.Logger
+	storage storage.StorageI
+	users   *UserLoader
+	logger  *logrus.Logger
 }
 
-func NewCommentService(strg storage.StorageI, logger *logrus.Logger, grpcConn grpcPkg.GrpcClientI) *CommentService {
+func NewCommentService(strg storage.StorageI, logger *logrus.Logger, users *UserLoader) *CommentService {
 	return &CommentService{
-		storage:    strg,
-		grpcClient: grpcConn,
-		logger:     logger,
+		storage: strg,
+		users:   users,
+		logger:  logger,
 	}
 }
 
 func (s *CommentService) Create(ctx context.Context, req *pb.Comment) (*pb.Comment, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	com, err := s.storage.Comment().Create(&repo.Comment{
-		UserID:      req.UserId,
+		UserID:      userID,
 		PostID:      req.PostId,
+		ParentID:    req.ParentId,
 		Description: req.Description,
 	})
 	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrInvalidArgument):
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Errorf(codes.NotFound, "post or parent comment is not found")
+		}
 		s.logger.WithError(err).Error("failed to create comment")
 		return nil, status.Errorf(codes.Internal, "Internal server error: %v", err)
 	}
 
-	user, err := s.grpcClient.UserService().Get(context.Background(), &user_service.IdRequest{Id: req.UserId})
+	user, err := s.users.LoadOne(ctx, userID)
 	if err != nil {
 		s.logger.WithError(err).Error("failed to get user")
 		return nil, status.Errorf(codes.Internal, "Internal server error: %v", err)
@@ -56,113 +69,217 @@ func (s *CommentService) Create(ctx context.Context, req *pb.Comment) (*pb.Comme
 }
 
 func (s *CommentService) Get(ctx context.Context, req *pb.GetComment) (*pb.Comment, error) {
-	user, err := s.grpcClient.UserService().Get(context.Background(), &user_service.IdRequest{Id: req.UserId})
+	com, err := s.storage.Comment().Get(req.Id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			s.logger.WithError(err).Error("failed to get user in comment_service")
-			return nil, status.Errorf(codes.NotFound, err.Error())
+			return nil, status.Errorf(codes.NotFound, "comment is not found")
 		}
+		s.logger.WithError(err).Error("failed to get comment in comment_service")
+		return nil, status.Errorf(codes.Internal, "failed to get comment: %v", err)
 	}
 
-	com, err := s.storage.Comment().Get(req.Id)
+	comments, err := s.parseComments(ctx, []*repo.Comment{com})
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			s.logger.WithError(err).Error("failed to get comment in comment_service")
-			return nil, status.Errorf(codes.NotFound, err.Error())
+		return nil, err
+	}
+
+	return comments[0], nil
+}
+
+// parseComments attaches the authors to the comments with one batch of
+// user lookups, tombstones are returned without one.
+func (s *CommentService) parseComments(ctx context.Context, comments []*repo.Comment) ([]*pb.Comment, error) {
+	ids := make([]int64, 0, len(comments))
+	for _, com := range comments {
+		if com.DeletedAt.IsZero() {
+			ids = append(ids, com.UserID)
 		}
 	}
 
-	return parseCommentModel(com, user), nil
+	users, err := s.users.Load(ctx, ids)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get users of the comments")
+		return nil, status.Errorf(codes.Internal, "failed to get users of the comments: %v", err)
+	}
+
+	result := make([]*pb.Comment, 0, len(comments))
+	for _, com := range comments {
+		if !com.DeletedAt.IsZero() {
+			result = append(result, parseCommentModel(com, nil))
+			continue
+		}
+		result = append(result, parseCommentModel(com, users[com.UserID]))
+	}
 
+	return result, nil
 }
 
 func parseCommentModel(c *repo.Comment, u *user_service.User) *pb.Comment {
-	return &pb.Comment{
-		Id:          c.ID,
-		UserId:      u.Id,
-		PostId:      c.PostID,
-		Description: c.Description,
-		CreatedAt:   c.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   c.UpdatedAt.Format(time.RFC3339),
-		User: &pb.CommentUser{
-			Id:           u.Id,
-			FirstName:    u.FirstName,
-			LastName:     u.LastName,
-			Email:        u.Email,
-			ProfileImage: u.ProfileImageUrl,
-		},
+	com := pb.Comment{
+		Id:           c.ID,
+		PostId:       c.PostID,
+		ParentId:     c.ParentID,
+		Depth:        c.Depth,
+		RepliesCount: c.RepliesCount,
+		Description:  c.Description,
+		CreatedAt:    c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    c.UpdatedAt.Format(time.RFC3339),
+		Deleted:      !c.DeletedAt.IsZero(),
+	}
+	// Tombstones don't tell who wrote them, the authors which no longer
+	// exist are shown by id alone.
+	if c.DeletedAt.IsZero() {
+		com.UserId = c.UserID
+	}
+	if u != nil {
+		com.User = parseCommentUser(u)
+	}
+
+	return &com
+}
+
+func parseCommentUser(u *user_service.User) *pb.CommentUser {
+	return &pb.CommentUser{
+		Id:           u.Id,
+		FirstName:    u.FirstName,
+		LastName:     u.LastName,
+		Email:        u.Email,
+		ProfileImage: u.ProfileImageUrl,
 	}
 }
 
 func (s *CommentService) GetAll(ctx context.Context, req *pb.GetAllCommentsRequest) (*pb.GetAllCommentsResponse, error) {
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
 	res, err := s.storage.Comment().GetAll(&repo.GetAllCommentsParams{
-		Limit:  req.Limit,
-		Page:   req.Page,
-		UserID: req.UserId,
-		PostID: req.PostId,
+		Limit:    req.Limit,
+		Page:     req.Page,
+		UserID:   req.UserId,
+		PostID:   req.PostId,
+		TopLevel: req.TopLevel,
+		After:    after,
 	})
 	if err != nil {
 		s.logger.WithError(err).Error("failed to get all comments in comment_service")
 		return nil, status.Errorf(codes.Internal, "failed to get all comments: %v", err)
 	}
 
-	response := pb.GetAllCommentsResponse{
-		Count:    res.Count,
-		Comments: make([]*pb.Comment, 0),
+	comments, err := s.parseComments(ctx, res.Comments)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, com := range res.Comments {
-		user, err := s.grpcClient.UserService().Get(context.Background(), &user_service.IdRequest{Id: com.UserID})
-		if err != nil {
-			s.logger.WithError(err).Error("failed to get user in get-all comments")
-			return nil, status.Errorf(codes.Internal, "failed to get user in get-all comments; %v", err)
+	return &pb.GetAllCommentsResponse{
+		Count:         res.Count,
+		Comments:      comments,
+		NextPageToken: encodePageToken(res.Next),
+	}, nil
+}
+
+// GetThread returns a comment with one page of its direct replies, the
+// deeper levels are fetched by calling it for a reply.
+func (s *CommentService) GetThread(ctx context.Context, req *pb.GetCommentThreadRequest) (*pb.CommentThread, error) {
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultRepliesLimit
+	}
+
+	com, err := s.storage.Comment().Get(req.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "comment is not found")
 		}
+		s.logger.WithError(err).Error("failed to get comment thread")
+		return nil, status.Errorf(codes.Internal, "failed to get comment thread: %v", err)
+	}
+
+	replies, err := s.storage.Comment().GetAll(&repo.GetAllCommentsParams{
+		Limit:    limit,
+		Page:     1,
+		ParentID: com.ID,
+		After:    after,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get comment replies")
+		return nil, status.Errorf(codes.Internal, "failed to get comment replies: %v", err)
+	}
 
-		response.Comments = append(response.Comments, parseCommentModel(com, user))
+	comments, err := s.parseComments(ctx, append([]*repo.Comment{com}, replies.Comments...))
+	if err != nil {
+		return nil, err
 	}
 
-	return &response, nil
+	return &pb.CommentThread{
+		Comment:       comments[0],
+		Replies:       comments[1:],
+		NextPageToken: encodePageToken(replies.Next),
+	}, nil
 }
 
 func (s *CommentService) Update(ctx context.Context, req *pb.Comment) (*pb.Comment, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	com, err := s.storage.Comment().Update(&repo.Comment{
 		ID:          req.Id,
+		UserID:      userID,
 		Description: req.Description,
 	})
 	if err != nil {
 		s.logger.WithError(err).Error("failed to update comment")
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Errorf(codes.NotFound, err.Error())
-		}
-		return nil, status.Errorf(codes.NotFound, "failed to update: %v", err)
+		return nil, commentWriteError(err, "failed to update comment")
 	}
 
-	user, err := s.grpcClient.UserService().Get(context.Background(), &user_service.IdRequest{Id: req.UserId})
+	user, err := s.users.LoadOne(ctx, userID)
 	if err != nil {
 		s.logger.WithError(err).Error("failed to get user when updating the comment")
 		return nil, status.Errorf(codes.Internal, "failed to get user when updating the comment: %v", err)
 	}
 
-	return &pb.Comment{
-		Id:          com.ID,
-		Description: com.Description,
-		UpdatedAt:   com.UpdatedAt.Format(time.RFC3339),
-		User: &pb.CommentUser{
-			Id:           user.Id,
-			FirstName:    user.FirstName,
-			LastName:     user.LastName,
-			Email:        user.Email,
-			ProfileImage: user.ProfileImageUrl,
-		},
-	}, nil
+	return parseCommentModel(com, user), nil
 }
 
 func (s *CommentService) Delete(ctx context.Context, req *pb.GetComment) (*emptypb.Empty, error) {
-	err := s.storage.Comment().Delete(req.Id)
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Moderators delete on behalf of the author.
+	if canModerate(ctx) {
+		com, err := s.storage.Comment().Get(req.Id)
+		if err != nil {
+			s.logger.WithError(err).Error("failed to get comment to delete")
+			return nil, commentWriteError(err, "failed to delete comment")
+		}
+		userID = com.UserID
+	}
+
+	err = s.storage.Comment().Delete(req.Id, userID)
 	if err != nil {
 		s.logger.WithError(err).Error("failed to delete comment")
-		return nil, status.Errorf(codes.Internal, "failed to delete comment	: %v", err)
+		return nil, commentWriteError(err, "failed to delete comment")
 	}
 
 	return &emptypb.Empty{}, nil
 }
+
+func commentWriteError(err error, msg string) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Errorf(codes.NotFound, "comment is not found")
+	case errors.Is(err, repo.ErrPermissionDenied):
+		return status.Errorf(codes.PermissionDenied, "you can't change other user's comment")
+	}
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
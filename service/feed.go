@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	pb "github.com/mirasildev/medium_post_service/genproto/post_service"
+	"github.com/mirasildev/medium_post_service/storage"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	defaultFeedLimit = 20
+	maxFeedLimit     = 100
+	maxSeenPosts     = 100
+)
+
+type FeedService struct {
+	pb.UnimplementedFeedServiceServer
+	storage storage.StorageI
+	users   *UserLoader
+	logger  *logrus.Logger
+}
+
+func NewFeedService(strg storage.StorageI, logger *logrus.Logger, users *UserLoader) *FeedService {
+	return &FeedService{
+		storage: strg,
+		users:   users,
+		logger:  logger,
+	}
+}
+
+// GetFeed returns the newest posts of the authors and categories the
+// caller follows. The posts marked seen are skipped unless IncludeSeen
+// is set.
+func (s *FeedService) GetFeed(ctx context.Context, req *pb.GetFeedRequest) (*pb.GetAllPostsResponse, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultFeedLimit
+	}
+	if limit > maxFeedLimit {
+		limit = maxFeedLimit
+	}
+
+	res, err := s.storage.Feed().GetFeed(&repo.GetFeedParams{
+		UserID:      userID,
+		Limit:       limit,
+		IncludeSeen: req.IncludeSeen,
+		After:       after,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get feed")
+		return nil, status.Errorf(codes.Internal, "failed to get feed: %v", err)
+	}
+
+	response := pb.GetAllPostsResponse{
+		Posts:         make([]*pb.Post, 0, len(res.Posts)),
+		Count:         res.Count,
+		NextPageToken: encodePageToken(res.Next),
+	}
+	for _, post := range res.Posts {
+		response.Posts = append(response.Posts, parsePostModel(post))
+	}
+
+	return &response, nil
+}
+
+func (s *FeedService) MarkSeen(ctx context.Context, req *pb.MarkPostsSeenRequest) (*emptypb.Empty, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.PostIds) > maxSeenPosts {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d posts can be marked at once", maxSeenPosts)
+	}
+
+	if err := s.storage.Feed().MarkSeen(userID, req.PostIds); err != nil {
+		s.logger.WithError(err).Error("failed to mark posts seen")
+		return nil, status.Errorf(codes.Internal, "failed to mark posts seen: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *FeedService) Follow(ctx context.Context, req *pb.FollowRequest) (*emptypb.Empty, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.UserId == userID {
+		return nil, status.Errorf(codes.InvalidArgument, "you can't follow yourself")
+	}
+
+	user, err := s.users.LoadOne(ctx, req.UserId)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get user to follow")
+		return nil, status.Errorf(codes.Internal, "failed to follow user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user is not found")
+	}
+
+	if err := s.storage.Follow().Follow(userID, req.UserId); err != nil {
+		s.logger.WithError(err).Error("failed to follow user")
+		return nil, status.Errorf(codes.Internal, "failed to follow user: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *FeedService) Unfollow(ctx context.Context, req *pb.FollowRequest) (*emptypb.Empty, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.Follow().Unfollow(userID, req.UserId); err != nil {
+		s.logger.WithError(err).Error("failed to unfollow user")
+		return nil, status.Errorf(codes.Internal, "failed to unfollow user: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *FeedService) Subscribe(ctx context.Context, req *pb.CategorySubscriptionRequest) (*emptypb.Empty, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.storage.CategorySubscription().Subscribe(userID, req.CategoryId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "category is not found")
+		}
+		s.logger.WithError(err).Error("failed to subscribe to category")
+		return nil, status.Errorf(codes.Internal, "failed to subscribe to category: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *FeedService) Unsubscribe(ctx context.Context, req *pb.CategorySubscriptionRequest) (*emptypb.Empty, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.storage.CategorySubscription().Unsubscribe(userID, req.CategoryId)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to unsubscribe from category")
+		return nil, status.Errorf(codes.Internal, "failed to unsubscribe from category: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
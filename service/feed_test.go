@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/mirasildev/medium_post_service/genproto/post_service"
+	pbu "github.com/mirasildev/medium_post_service/genproto/user_service"
+	"github.com/mirasildev/medium_post_service/pkg/auth"
+	"github.com/mirasildev/medium_post_service/pkg/logger"
+	"github.com/mirasildev/medium_post_service/storage"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeFollowRepo struct {
+	follows map[[2]int64]bool
+}
+
+func (f *fakeFollowRepo) Follow(followerID, followeeID int64) error {
+	f.follows[[2]int64{followerID, followeeID}] = true
+	return nil
+}
+
+func (f *fakeFollowRepo) Unfollow(followerID, followeeID int64) error {
+	delete(f.follows, [2]int64{followerID, followeeID})
+	return nil
+}
+
+type fakeFeedStorage struct {
+	storage.StorageI
+	follows *fakeFollowRepo
+}
+
+func (f *fakeFeedStorage) Follow() repo.FollowStorageI {
+	return f.follows
+}
+
+func TestFollow(t *testing.T) {
+	users := newFakeUserService()
+	users.missing[3] = true
+	follows := &fakeFollowRepo{follows: make(map[[2]int64]bool)}
+	s := NewFeedService(
+		&fakeFeedStorage{follows: follows},
+		logger.New(),
+		NewUserLoader(&fakeGrpcClient{userService: users}, nil, time.Minute, 1, logger.New()),
+	)
+	ctx := auth.NewContext(context.Background(), &pbu.AuthPayload{UserId: 1})
+
+	_, err := s.Follow(ctx, &pb.FollowRequest{UserId: 1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.Follow(ctx, &pb.FollowRequest{UserId: 3})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = s.Follow(ctx, &pb.FollowRequest{UserId: 2})
+	require.NoError(t, err)
+	require.True(t, follows.follows[[2]int64{1, 2}])
+
+	_, err = s.Follow(context.Background(), &pb.FollowRequest{UserId: 2})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	require.Len(t, follows.follows, 1)
+}
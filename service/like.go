@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	pb "github.com/mirasildev/medium_post_service/genproto/post_service"
 	"github.com/mirasildev/medium_post_service/storage"
@@ -11,41 +12,104 @@ import (
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type LikeService struct {
 	pb.UnimplementedLikeServiceServer
 	storage storage.StorageI
+	users   *UserLoader
 	logger  *logrus.Logger
 }
 
-func NewLikeService(strg storage.StorageI, logger *logrus.Logger) *LikeService {
+func NewLikeService(strg storage.StorageI, logger *logrus.Logger, users *UserLoader) *LikeService {
 	return &LikeService{
 		storage: strg,
+		users:   users,
 		logger:  logger,
 	}
 }
 
+// CreateOrUpdate toggles the reaction of the caller, sending the stored
+// reaction again takes it back and returns a like without a reaction.
 func (s *LikeService) CreateOrUpdate(ctx context.Context, req *pb.Like) (*pb.Like, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	like, err := s.storage.Like().CreateOrUpdate(&repo.Like{
 		PostID: req.PostId,
-		UserID: req.UserId,
+		UserID: userID,
 		Status: req.Status,
 	})
 	if err != nil {
 		s.logger.WithError(err).Error("failed to create-update like")
-		return nil, status.Errorf(codes.Internal, "Internal server error: %v", err)
+		return nil, likeWriteError(err, "failed to create-update like")
+	}
+	if like == nil {
+		return &pb.Like{
+			PostId:   req.PostId,
+			UserId:   userID,
+			Reaction: pb.Reaction_REACTION_NONE,
+		}, nil
 	}
 
 	return parseLikeModel(like), nil
 }
 
+func (s *LikeService) SetReaction(ctx context.Context, req *pb.SetReactionRequest) (*pb.Like, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction, err := parseReaction(req.Reaction)
+	if err != nil {
+		return nil, err
+	}
+	if reaction == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "reaction is required, use RemoveReaction to take it back")
+	}
+
+	like, err := s.storage.Like().SetReaction(req.PostId, userID, reaction == repo.ReactionLike)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to set reaction")
+		return nil, likeWriteError(err, "failed to set reaction")
+	}
+
+	return parseLikeModel(like), nil
+}
+
+func (s *LikeService) RemoveReaction(ctx context.Context, req *pb.RemoveReactionRequest) (*emptypb.Empty, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.storage.Like().RemoveReaction(req.PostId, userID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to remove reaction")
+		return nil, likeWriteError(err, "failed to remove reaction")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func likeWriteError(err error, msg string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Errorf(codes.NotFound, "post is not found")
+	}
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
+
 func parseLikeModel(like *repo.Like) *pb.Like {
 	return &pb.Like{
-		Id:     like.ID,
-		PostId: like.PostID,
-		UserId: like.UserID,
-		Status: like.Status,
+		Id:       like.ID,
+		PostId:   like.PostID,
+		UserId:   like.UserID,
+		Status:   like.Status,
+		Reaction: reactionToPb(repo.LikeReaction(like.Status)),
 	}
 }
 
@@ -65,6 +129,9 @@ func (s *LikeService) Get(ctx context.Context, req *pb.GetLike) (*pb.Like, error
 func (s *LikeService) GetAllLikesCount(ctx context.Context, req *pb.GetLike) (*pb.AllLikesCount, error) {
 	res, err := s.storage.Like().GetLikesDislikesCount(req.PostId)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "post is not found")
+		}
 		s.logger.WithError(err).Error("failed to get likes and dislikes count")
 		return nil, status.Errorf(codes.Internal, "failed to get likes and dislikes count: %v", err)
 	}
@@ -74,3 +141,118 @@ func (s *LikeService) GetAllLikesCount(ctx context.Context, req *pb.GetLike) (*p
 		DislikesCount: res.DislikesCount,
 	}, nil
 }
+
+func (s *LikeService) ListLikers(ctx context.Context, req *pb.ListLikersRequest) (*pb.ListLikersResponse, error) {
+	reaction, err := parseReaction(req.Reaction)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	res, err := s.storage.Like().GetLikers(&repo.GetLikersParams{
+		PostID:   req.PostId,
+		Reaction: reaction,
+		Limit:    req.Limit,
+		Page:     req.Page,
+		After:    after,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to list likers")
+		return nil, status.Errorf(codes.Internal, "failed to list likers: %v", err)
+	}
+
+	ids := make([]int64, 0, len(res.Likes))
+	for _, like := range res.Likes {
+		ids = append(ids, like.UserID)
+	}
+
+	users, err := s.users.Load(ctx, ids)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get users of the likes")
+		return nil, status.Errorf(codes.Internal, "failed to get users of the likes: %v", err)
+	}
+
+	response := pb.ListLikersResponse{
+		Likers:        make([]*pb.Liker, 0, len(res.Likes)),
+		Count:         res.Count,
+		NextPageToken: encodePageToken(res.Next),
+	}
+	for _, like := range res.Likes {
+		liker := pb.Liker{
+			User:      &pb.CommentUser{Id: like.UserID},
+			Reaction:  reactionToPb(repo.LikeReaction(like.Status)),
+			CreatedAt: like.CreatedAt.Format(time.RFC3339),
+		}
+		if u := users[like.UserID]; u != nil {
+			liker.User = parseCommentUser(u)
+		}
+		response.Likers = append(response.Likers, &liker)
+	}
+
+	return &response, nil
+}
+
+func (s *LikeService) ListLikedPosts(ctx context.Context, req *pb.ListLikedPostsRequest) (*pb.GetAllPostsResponse, error) {
+	reaction, err := parseReaction(req.Reaction)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	// The reactions are shown to whoever is signed in, not to UserId.
+	viewerID, _ := actingUserID(ctx)
+
+	res, err := s.storage.Like().GetLikedPosts(&repo.GetLikedPostsParams{
+		UserID:   req.UserId,
+		Reaction: reaction,
+		ViewerID: viewerID,
+		Limit:    req.Limit,
+		Page:     req.Page,
+		After:    after,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to list liked posts")
+		return nil, status.Errorf(codes.Internal, "failed to list liked posts: %v", err)
+	}
+
+	response := pb.GetAllPostsResponse{
+		Posts:         make([]*pb.Post, 0, len(res.Posts)),
+		Count:         res.Count,
+		NextPageToken: encodePageToken(res.Next),
+	}
+	for _, post := range res.Posts {
+		response.Posts = append(response.Posts, parsePostModel(post))
+	}
+
+	return &response, nil
+}
+
+func parseReaction(r pb.Reaction) (string, error) {
+	switch r {
+	case pb.Reaction_REACTION_NONE:
+		return "", nil
+	case pb.Reaction_REACTION_LIKE:
+		return repo.ReactionLike, nil
+	case pb.Reaction_REACTION_DISLIKE:
+		return repo.ReactionDislike, nil
+	}
+	return "", status.Errorf(codes.InvalidArgument, "unknown reaction %d", r)
+}
+
+func reactionToPb(reaction string) pb.Reaction {
+	switch reaction {
+	case repo.ReactionLike:
+		return pb.Reaction_REACTION_LIKE
+	case repo.ReactionDislike:
+		return pb.Reaction_REACTION_DISLIKE
+	}
+	return pb.Reaction_REACTION_NONE
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mirasildev/medium_post_service/storage/repo"
+)
+
+var errInvalidPageToken = errors.New("invalid page token")
+
+// pageToken is the keyset of the last row of a page. Clients get it
+// base64 encoded and must treat it as opaque.
+type pageToken struct {
+	CreatedAt time.Time `json:"c"`
+	ID        int64     `json:"i"`
+	Value     float64   `json:"v,omitempty"`
+}
+
+func encodePageToken(k *repo.Keyset) string {
+	if k == nil {
+		return ""
+	}
+
+	data, _ := json.Marshal(pageToken{
+		CreatedAt: k.CreatedAt,
+		ID:        k.ID,
+		Value:     k.Value,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken returns nil for an empty token, that is the first page.
+func decodePageToken(token string) (*repo.Keyset, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+
+	var t pageToken
+	if err := json.Unmarshal(data, &t); err != nil || t.ID <= 0 || t.CreatedAt.IsZero() {
+		return nil, errInvalidPageToken
+	}
+
+	return &repo.Keyset{
+		CreatedAt: t.CreatedAt,
+		ID:        t.ID,
+		Value:     t.Value,
+	}, nil
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageToken(t *testing.T) {
+	k := &repo.Keyset{
+		CreatedAt: time.Date(2022, 12, 1, 10, 30, 0, 123456000, time.UTC),
+		ID:        42,
+	}
+
+	token := encodePageToken(k)
+	require.NotEmpty(t, token)
+
+	decoded, err := decodePageToken(token)
+	require.NoError(t, err)
+	require.True(t, k.CreatedAt.Equal(decoded.CreatedAt))
+	require.Equal(t, k.ID, decoded.ID)
+
+	k.Value = 0.1 + 0.2
+	decoded, err = decodePageToken(encodePageToken(k))
+	require.NoError(t, err)
+	require.Equal(t, k.Value, decoded.Value)
+
+	require.Empty(t, encodePageToken(nil))
+
+	decoded, err = decodePageToken("")
+	require.NoError(t, err)
+	require.Nil(t, decoded)
+}
+
+func TestDecodeInvalidPageToken(t *testing.T) {
+	for _, token := range []string{"not base64!", "bm90IGpzb24", "e30", "eyJpIjotMX0"} {
+		_, err := decodePageToken(token)
+		require.ErrorIs(t, err, errInvalidPageToken, token)
+	}
+}
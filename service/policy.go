@@ -0,0 +1,86 @@
+package service
+
+import "github.com/mirasildev/medium_post_service/pkg/auth"
+
+var (
+	public = auth.Policy{Public: true}
+	// authenticated lets any signed in user call the RPC, ownership of
+	// the content is checked by the handler.
+	authenticated = auth.Policy{}
+	admins        = auth.Policy{Roles: []string{auth.UserTypeAdmin}}
+)
+
+// Policies declares who may call every RPC of the service.
+var Policies = auth.Policies{
+	"/genproto.PostService/Create":                 authenticated,
+	"/genproto.PostService/Get":                    public,
+	"/genproto.PostService/GetBySlug":              public,
+	"/genproto.PostService/GetByPublicId":          public,
+	"/genproto.PostService/GetAll":                 public,
+	"/genproto.PostService/SearchPosts":            public,
+	"/genproto.PostService/Update":                 authenticated,
+	"/genproto.PostService/Delete":                 authenticated,
+	"/genproto.PostService/Restore":                authenticated,
+	"/genproto.PostService/ListTrash":              authenticated,
+	"/genproto.PostService/Publish":                authenticated,
+	"/genproto.PostService/Unpublish":              authenticated,
+	"/genproto.PostService/Archive":                authenticated,
+	"/genproto.PostService/SchedulePublish":        authenticated,
+	"/genproto.PostService/ReschedulePublish":      authenticated,
+	"/genproto.PostService/CancelScheduledPublish": authenticated,
+	"/genproto.PostService/ListRevisions":          public,
+	"/genproto.PostService/GetRevision":            public,
+	"/genproto.PostService/DiffRevisions":          public,
+	"/genproto.PostService/RestoreRevision":        authenticated,
+	"/genproto.PostService/RecountCounters":        admins,
+
+	"/genproto.CategoryService/Create":            admins,
+	"/genproto.CategoryService/Get":               public,
+	"/genproto.CategoryService/GetAll":            public,
+	"/genproto.CategoryService/Update":            admins,
+	"/genproto.CategoryService/Delete":            admins,
+	"/genproto.CategoryService/MergeCategories":   admins,
+	"/genproto.CategoryService/GetCategoryBySlug": public,
+	"/genproto.CategoryService/GetCategoryTree":   public,
+	"/genproto.CategoryService/MoveCategory":      admins,
+
+	"/genproto.CommentService/Create":    authenticated,
+	"/genproto.CommentService/Get":       public,
+	"/genproto.CommentService/GetAll":    public,
+	"/genproto.CommentService/Update":    authenticated,
+	"/genproto.CommentService/Delete":    authenticated,
+	"/genproto.CommentService/GetThread": public,
+
+	"/genproto.LikeService/CreateOrUpdate":   authenticated,
+	"/genproto.LikeService/SetReaction":      authenticated,
+	"/genproto.LikeService/RemoveReaction":   authenticated,
+	"/genproto.LikeService/Get":              public,
+	"/genproto.LikeService/GetAllLikesCount": public,
+	"/genproto.LikeService/ListLikers":       public,
+	"/genproto.LikeService/ListLikedPosts":   public,
+
+	"/genproto.FeedService/GetFeed":     authenticated,
+	"/genproto.FeedService/MarkSeen":    authenticated,
+	"/genproto.FeedService/Follow":      authenticated,
+	"/genproto.FeedService/Unfollow":    authenticated,
+	"/genproto.FeedService/Subscribe":   authenticated,
+	"/genproto.FeedService/Unsubscribe": authenticated,
+
+	"/genproto.BookmarkService/Add":                   authenticated,
+	"/genproto.BookmarkService/Remove":                authenticated,
+	"/genproto.BookmarkService/List":                  authenticated,
+	"/genproto.BookmarkService/IsBookmarked":          authenticated,
+	"/genproto.BookmarkService/CreateReadingList":     authenticated,
+	"/genproto.BookmarkService/UpdateReadingList":     authenticated,
+	"/genproto.BookmarkService/DeleteReadingList":     authenticated,
+	"/genproto.BookmarkService/GetReadingList":        public,
+	"/genproto.BookmarkService/ListReadingLists":      public,
+	"/genproto.BookmarkService/AddToReadingList":      authenticated,
+	"/genproto.BookmarkService/RemoveFromReadingList": authenticated,
+	"/genproto.BookmarkService/ReorderReadingList":    authenticated,
+
+	"/genproto.TagService/SetPostTags": authenticated,
+	"/genproto.TagService/GetPostTags": public,
+	"/genproto.TagService/GetAll":      public,
+	"/genproto.TagService/GetPopular":  public,
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	pbu "github.com/mirasildev/medium_post_service/genproto/user_service"
+	"github.com/mirasildev/medium_post_service/pkg/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPolicies(t *testing.T) {
+	var (
+		user      = &pbu.AuthPayload{UserId: 1, UserType: auth.UserTypeUser}
+		moderator = &pbu.AuthPayload{UserId: 2, UserType: auth.UserTypeModerator}
+		admin     = &pbu.AuthPayload{UserId: 3, UserType: auth.UserTypeAdmin}
+		granted   = &pbu.AuthPayload{UserId: 4, UserType: auth.UserTypeUser, HasPermission: true}
+	)
+
+	tests := []struct {
+		method  string
+		payload *pbu.AuthPayload
+		want    codes.Code
+	}{
+		{"/genproto.PostService/GetAll", nil, codes.OK},
+		{"/genproto.PostService/Get", nil, codes.OK},
+		{"/genproto.PostService/GetBySlug", nil, codes.OK},
+		{"/genproto.PostService/Create", nil, codes.Unauthenticated},
+		{"/genproto.PostService/Create", user, codes.OK},
+		{"/genproto.PostService/Delete", nil, codes.Unauthenticated},
+		{"/genproto.PostService/Delete", user, codes.OK},
+		{"/genproto.PostService/Delete", moderator, codes.OK},
+		{"/genproto.PostService/ListTrash", nil, codes.Unauthenticated},
+		{"/genproto.PostService/RecountCounters", user, codes.PermissionDenied},
+		{"/genproto.PostService/RecountCounters", admin, codes.OK},
+
+		{"/genproto.CategoryService/GetAll", nil, codes.OK},
+		{"/genproto.CategoryService/Create", nil, codes.Unauthenticated},
+		{"/genproto.CategoryService/Create", user, codes.PermissionDenied},
+		{"/genproto.CategoryService/Create", moderator, codes.PermissionDenied},
+		{"/genproto.CategoryService/Create", admin, codes.OK},
+		{"/genproto.CategoryService/Create", granted, codes.OK},
+		{"/genproto.CategoryService/Update", user, codes.PermissionDenied},
+		{"/genproto.CategoryService/Update", admin, codes.OK},
+		{"/genproto.CategoryService/Delete", moderator, codes.PermissionDenied},
+		{"/genproto.CategoryService/Delete", admin, codes.OK},
+		{"/genproto.CategoryService/MergeCategories", user, codes.PermissionDenied},
+		{"/genproto.CategoryService/MergeCategories", admin, codes.OK},
+		{"/genproto.CategoryService/GetCategoryTree", nil, codes.OK},
+		{"/genproto.CategoryService/MoveCategory", moderator, codes.PermissionDenied},
+
+		{"/genproto.CommentService/GetAll", nil, codes.OK},
+		{"/genproto.CommentService/Create", nil, codes.Unauthenticated},
+		{"/genproto.CommentService/Delete", user, codes.OK},
+		{"/genproto.CommentService/Delete", moderator, codes.OK},
+
+		{"/genproto.FeedService/GetFeed", nil, codes.Unauthenticated},
+		{"/genproto.FeedService/GetFeed", user, codes.OK},
+		{"/genproto.BookmarkService/Add", nil, codes.Unauthenticated},
+		{"/genproto.BookmarkService/GetReadingList", nil, codes.OK},
+
+		{"/genproto.TagService/SetPostTags", nil, codes.Unauthenticated},
+		{"/genproto.LikeService/CreateOrUpdate", nil, codes.Unauthenticated},
+
+		// RPCs missing from the table are not public.
+		{"/genproto.PostService/Unknown", nil, codes.Unauthenticated},
+		{"/genproto.PostService/Unknown", user, codes.OK},
+	}
+
+	for _, tc := range tests {
+		name := tc.method + " as anonymous"
+		if tc.payload != nil {
+			name = tc.method + " as " + tc.payload.UserType
+		}
+
+		t.Run(name, func(t *testing.T) {
+			err := Policies.Authorize(tc.method, tc.payload)
+			require.Equal(t, tc.want, status.Code(err))
+		})
+	}
+}
+
+func TestCanModerate(t *testing.T) {
+	tests := []struct {
+		userType string
+		want     bool
+	}{
+		{auth.UserTypeUser, false},
+		{auth.UserTypeModerator, true},
+		{auth.UserTypeAdmin, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.userType, func(t *testing.T) {
+			ctx := auth.NewContext(context.Background(), &pbu.AuthPayload{UserType: tc.userType})
+			require.Equal(t, tc.want, canModerate(ctx))
+		})
+	}
+
+	require.False(t, canModerate(context.Background()))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"net"
+	"strings"
 	"time"
 
 	pb "github.com/mirasildev/medium_post_service/genproto/post_service"
@@ -15,27 +17,53 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+const (
+	defaultTrashLimit = 20
+	maxTrashLimit     = 100
+
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
 type PostService struct {
 	pb.UnimplementedPostServiceServer
-	storage storage.StorageI
-	logger  *logrus.Logger
+	storage    storage.StorageI
+	views      storage.ViewStorageI
+	viewWindow time.Duration
+	// trustedProxies may forward the IPs of anonymous viewers.
+	trustedProxies []*net.IPNet
+	logger         *logrus.Logger
 }
 
-func NewPostService(strg storage.StorageI, logger *logrus.Logger) *PostService {
+// NewPostService returns the service, views may be nil to write every
+// view straight to Postgres without de-duplication.
+func NewPostService(strg storage.StorageI, views storage.ViewStorageI, viewWindow time.Duration, trustedProxies []*net.IPNet, logger *logrus.Logger) *PostService {
 	return &PostService{
-		storage: strg,
-		logger:  logger,
+		storage:        strg,
+		views:          views,
+		viewWindow:     viewWindow,
+		trustedProxies: trustedProxies,
+		logger:         logger,
 	}
 }
 
 func (s *PostService) Create(ctx context.Context, req *pb.Post) (*pb.Post, error) {
+	if req.Status != "" && req.Status != repo.PostStatusDraft && req.Status != repo.PostStatusPublished {
+		return nil, status.Errorf(codes.InvalidArgument, "post can only be created as %s or %s", repo.PostStatusDraft, repo.PostStatusPublished)
+	}
+
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	post, err := s.storage.Post().Create(&repo.Post{
 		Title:       req.Title,
 		Description: req.Description,
 		ImageUrl:    req.ImageUrl,
-		UserID:      req.UserId,
+		UserID:      userID,
 		CategoryID:  req.CategoryId,
+		Status:      req.Status,
 	})
 	if err != nil {
 		s.logger.WithError(err).Error("failed to create post")
@@ -45,16 +73,40 @@ func (s *PostService) Create(ctx context.Context, req *pb.Post) (*pb.Post, error
 }
 
 func parsePostModel(p *repo.Post) *pb.Post {
-	return &pb.Post{
-		Id:          p.ID,
-		Title:       p.Title,
-		Description: p.Description,
-		ImageUrl:    p.ImageUrl,
-		UserId:      p.UserID,
-		CategoryId:  p.CategoryID,
-		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
-		ViewsCount:  p.ViewsCount,
+	post := pb.Post{
+		Id:             p.ID,
+		Title:          p.Title,
+		Description:    p.Description,
+		ImageUrl:       p.ImageUrl,
+		UserId:         p.UserID,
+		CategoryId:     p.CategoryID,
+		CreatedAt:      p.CreatedAt.Format(time.RFC3339),
+		ViewsCount:     p.ViewsCount,
+		Status:         p.Status,
+		LikesCount:     p.LikesCount,
+		DislikesCount:  p.DislikesCount,
+		CommentsCount:  p.CommentsCount,
+		ViewerReaction: reactionToPb(p.ViewerReaction),
+	}
+	if !p.PublishedAt.IsZero() {
+		post.PublishedAt = p.PublishedAt.Format(time.RFC3339)
+	}
+	if !p.PublishAt.IsZero() {
+		post.PublishAt = p.PublishAt.Format(time.RFC3339)
 	}
+	if !p.DeletedAt.IsZero() {
+		post.DeletedAt = p.DeletedAt.Format(time.RFC3339)
+	}
+	post.PublicId = p.PublicID
+	post.Slug = p.Slug
+
+	return &post
+}
+
+// canViewPost reports whether the caller may read the post, the posts
+// which aren't published are shown only to their authors and moderators.
+func canViewPost(ctx context.Context, p *repo.Post) bool {
+	return p.Status == repo.PostStatusPublished || isActingUser(ctx, p.UserID) || canModerate(ctx)
 }
 
 func (s *PostService) Get(ctx context.Context, req *pb.GetPost) (*pb.Post, error) {
@@ -66,27 +118,126 @@ func (s *PostService) Get(ctx context.Context, req *pb.GetPost) (*pb.Post, error
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get post: %v", err)
 	}
+	if !canViewPost(ctx, post) {
+		return nil, status.Errorf(codes.NotFound, "post is not found")
+	}
+
+	if post.Status == repo.PostStatusPublished {
+		s.recordView(ctx, post.ID)
+	}
+
+	return parsePostModel(post), nil
+}
+
+// GetBySlug also finds the posts by their previous slugs, Moved tells the
+// client to redirect to CanonicalSlug.
+func (s *PostService) GetBySlug(ctx context.Context, req *pb.GetPostBySlugRequest) (*pb.PostBySlug, error) {
+	if req.Slug == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "slug is required")
+	}
+
+	post, err := s.storage.Post().GetBySlug(req.Slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "post is not found")
+		}
+		s.logger.WithError(err).Error("failed to get post by slug")
+		return nil, status.Errorf(codes.Internal, "failed to get post: %v", err)
+	}
+	if !canViewPost(ctx, post) {
+		return nil, status.Errorf(codes.NotFound, "post is not found")
+	}
+
+	if post.Status == repo.PostStatusPublished {
+		s.recordView(ctx, post.ID)
+	}
+
+	return &pb.PostBySlug{
+		Post:          parsePostModel(post),
+		CanonicalSlug: post.Slug,
+		Moved:         post.Slug != req.Slug,
+	}, nil
+}
+
+func (s *PostService) GetByPublicId(ctx context.Context, req *pb.GetPostByPublicIdRequest) (*pb.Post, error) {
+	post, err := s.storage.Post().GetByPublicID(req.PublicId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "post is not found")
+		}
+		s.logger.WithError(err).Error("failed to get post by public id")
+		return nil, status.Errorf(codes.Internal, "failed to get post: %v", err)
+	}
+	if !canViewPost(ctx, post) {
+		return nil, status.Errorf(codes.NotFound, "post is not found")
+	}
+
+	if post.Status == repo.PostStatusPublished {
+		s.recordView(ctx, post.ID)
+	}
 
 	return parsePostModel(post), nil
 }
 
+// postSorts maps the sorts of GetAll to the repo ones, the unspecified
+// sort leaves the order to SortByDate.
+var postSorts = map[pb.PostSort]string{
+	pb.PostSort_POST_SORT_UNSPECIFIED:    "",
+	pb.PostSort_POST_SORT_NEWEST:         repo.PostSortNewest,
+	pb.PostSort_POST_SORT_OLDEST:         repo.PostSortOldest,
+	pb.PostSort_POST_SORT_MOST_VIEWED:    repo.PostSortMostViewed,
+	pb.PostSort_POST_SORT_MOST_LIKED:     repo.PostSortMostLiked,
+	pb.PostSort_POST_SORT_MOST_COMMENTED: repo.PostSortMostCommented,
+	pb.PostSort_POST_SORT_TRENDING:       repo.PostSortTrending,
+}
+
 func (s *PostService) GetAll(ctx context.Context, req *pb.GetAllPostsRequest) (*pb.GetAllPostsResponse, error) {
+	tags := make([]string, 0, len(req.Tags))
+	for _, tag := range req.Tags {
+		if tag = normalizeTag(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	// Anonymous callers get the posts without reactions.
+	viewerID, _ := actingUserID(ctx)
+
+	sort, ok := postSorts[req.Sort]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown sort %d", req.Sort)
+	}
+
 	res, err := s.storage.Post().GetAll(&repo.GetAllPostsParams{
-		Limit:      req.Limit,
-		Page:       req.Page,
-		Search:     req.Search,
-		CategoryID: req.CategoryId,
-		UserID:     req.UserId,
-		SortByDate: req.SortByDate,
+		Limit:                req.Limit,
+		Page:                 req.Page,
+		Search:               req.Search,
+		CategoryID:           req.CategoryId,
+		UserID:               req.UserId,
+		Sort:                 sort,
+		SortByDate:           req.SortByDate,
+		IncludeDrafts:        req.IncludeDrafts && isActingUser(ctx, req.UserId),
+		Tags:                 tags,
+		After:                after,
+		ViewerID:             viewerID,
+		IncludeSubcategories: req.IncludeSubcategories,
 	})
 	if err != nil {
+		if errors.Is(err, repo.ErrInvalidArgument) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		s.logger.WithError(err).Error("failed to get all posts")
 		return nil, status.Errorf(codes.Internal, "failed to get all posts: %v", err)
 	}
 
 	response := pb.GetAllPostsResponse{
-		Count: res.Count,
-		Posts: make([]*pb.Post, 0),
+		Count:         res.Count,
+		Posts:         make([]*pb.Post, 0),
+		NextPageToken: encodePageToken(res.Next),
 	}
 
 	for _, post := range res.Posts {
@@ -96,10 +247,56 @@ func (s *PostService) GetAll(ctx context.Context, req *pb.GetAllPostsRequest) (*
 	return &response, nil
 }
 
+func (s *PostService) SearchPosts(ctx context.Context, req *pb.SearchPostsRequest) (*pb.SearchPostsResponse, error) {
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "query is required")
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	res, err := s.storage.Post().Search(&repo.SearchPostsParams{
+		Query:      req.Query,
+		Limit:      limit,
+		Page:       req.Page,
+		CategoryID: req.CategoryId,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to search posts")
+		return nil, status.Errorf(codes.Internal, "failed to search posts: %v", err)
+	}
+
+	response := pb.SearchPostsResponse{
+		Count:   res.Count,
+		Results: make([]*pb.SearchPostResult, 0),
+	}
+
+	for _, r := range res.Results {
+		response.Results = append(response.Results, &pb.SearchPostResult{
+			Post:                 parsePostModel(r.Post),
+			Rank:                 r.Rank,
+			TitleHighlight:       r.TitleHighlight,
+			DescriptionHighlight: r.DescriptionHighlight,
+		})
+	}
+
+	return &response, nil
+}
+
 func (s *PostService) Update(ctx context.Context, req *pb.UpdatePostRequest) (*pb.Post, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	post, err := s.storage.Post().UpdatePost(&repo.Post{
 		ID:          req.Id,
-		UserID:      req.UserId,
+		UserID:      userID,
 		Title:       req.Title,
 		Description: req.Description,
 		ImageUrl:    req.ImageUrl,
@@ -114,26 +311,223 @@ func (s *PostService) Update(ctx context.Context, req *pb.UpdatePostRequest) (*p
 		return nil, status.Errorf(codes.Internal, "failed to update: %v", err)
 	}
 
-	return &pb.Post{
-		Id:          post.ID,
-		Title:       post.Title,
-		Description: post.Description,
-		ImageUrl:    post.ImageUrl,
-		UserId:      post.UserID,
-		CategoryId:  post.CategoryID,
-		CreatedAt:   post.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   post.UpdatedAt.Format(time.RFC3339),
-		ViewsCount:  post.ViewsCount,
-	}, nil
+	result := parsePostModel(post)
+	result.UpdatedAt = post.UpdatedAt.Format(time.RFC3339)
 
+	return result, nil
 }
 
 func (s *PostService) Delete(ctx context.Context, req *pb.DeletePost) (*emptypb.Empty, error) {
-	err := s.storage.Post().DeletePost(req.Id, req.UserId)
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if canModerate(ctx) {
+		post, err := s.storage.Post().Get(req.Id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, status.Errorf(codes.NotFound, "post is not found")
+			}
+			s.logger.WithError(err).Error("failed to get post to delete")
+			return nil, status.Errorf(codes.Internal, "failed to delete post: %v", err)
+		}
+		userID = post.UserID
+	}
+
+	err = s.storage.Post().DeletePost(req.Id, userID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "post is not found")
+		}
 		s.logger.WithError(err).Error("failed to delete post")
 		return nil, status.Errorf(codes.Internal, "failed to delete post: %v", err)
 	}
 
 	return &emptypb.Empty{}, nil
 }
+
+// Restore takes a post of the caller out of the trash.
+func (s *PostService) Restore(ctx context.Context, req *pb.ChangePostStatusRequest) (*pb.Post, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.storage.Post().RestorePost(req.Id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "post is not found in your trash")
+		}
+		s.logger.WithError(err).Error("failed to restore post")
+		return nil, status.Errorf(codes.Internal, "failed to restore post: %v", err)
+	}
+
+	return parsePostModel(post), nil
+}
+
+func (s *PostService) ListTrash(ctx context.Context, req *pb.ListTrashRequest) (*pb.GetAllPostsResponse, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultTrashLimit
+	}
+	if limit > maxTrashLimit {
+		limit = maxTrashLimit
+	}
+
+	res, err := s.storage.Post().GetTrash(&repo.GetTrashParams{
+		UserID: userID,
+		Limit:  limit,
+		After:  after,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to list trash")
+		return nil, status.Errorf(codes.Internal, "failed to list trash: %v", err)
+	}
+
+	response := pb.GetAllPostsResponse{
+		Count:         res.Count,
+		Posts:         make([]*pb.Post, 0, len(res.Posts)),
+		NextPageToken: encodePageToken(res.Next),
+	}
+	for _, post := range res.Posts {
+		response.Posts = append(response.Posts, parsePostModel(post))
+	}
+
+	return &response, nil
+}
+
+func (s *PostService) Publish(ctx context.Context, req *pb.ChangePostStatusRequest) (*pb.Post, error) {
+	return s.changeStatus(ctx, req, repo.PostStatusPublished)
+}
+
+func (s *PostService) Unpublish(ctx context.Context, req *pb.ChangePostStatusRequest) (*pb.Post, error) {
+	return s.changeStatus(ctx, req, repo.PostStatusDraft)
+}
+
+func (s *PostService) Archive(ctx context.Context, req *pb.ChangePostStatusRequest) (*pb.Post, error) {
+	return s.changeStatus(ctx, req, repo.PostStatusArchived)
+}
+
+func (s *PostService) changeStatus(ctx context.Context, req *pb.ChangePostStatusRequest, postStatus string) (*pb.Post, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.storage.Post().UpdateStatus(req.Id, userID, postStatus)
+	if err != nil {
+		s.logger.WithError(err).Errorf("failed to change post status to %s", postStatus)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "you can't change other user's post")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to change post status: %v", err)
+	}
+
+	return parsePostModel(post), nil
+}
+
+func (s *PostService) SchedulePublish(ctx context.Context, req *pb.SchedulePostRequest) (*pb.Post, error) {
+	publishAt, err := parsePublishAt(req.PublishAt)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.storage.Post().SchedulePublish(req.Id, userID, publishAt)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to schedule post")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "post is not found or it is not an unscheduled draft")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to schedule post: %v", err)
+	}
+
+	return parsePostModel(post), nil
+}
+
+func (s *PostService) ReschedulePublish(ctx context.Context, req *pb.SchedulePostRequest) (*pb.Post, error) {
+	publishAt, err := parsePublishAt(req.PublishAt)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.storage.Post().ReschedulePublish(req.Id, userID, publishAt)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to reschedule post")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "post is not found or it is not scheduled")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to reschedule post: %v", err)
+	}
+
+	return parsePostModel(post), nil
+}
+
+func (s *PostService) CancelScheduledPublish(ctx context.Context, req *pb.ChangePostStatusRequest) (*pb.Post, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.storage.Post().CancelScheduledPublish(req.Id, userID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to cancel scheduled post")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "post is not found or it is not scheduled")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to cancel scheduled post: %v", err)
+	}
+
+	return parsePostModel(post), nil
+}
+
+func parsePublishAt(value string) (time.Time, error) {
+	publishAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "publish_at must be in RFC3339 format: %v", err)
+	}
+
+	if !publishAt.After(time.Now()) {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "publish_at must be in the future")
+	}
+
+	return publishAt, nil
+}
+
+// RecountCounters repairs the like, dislike and comment counters of a post,
+// or of every post when PostId is 0.
+func (s *PostService) RecountCounters(ctx context.Context, req *pb.RecountPostCountersRequest) (*pb.RecountPostCountersResponse, error) {
+	ids, err := s.storage.Post().RecountCounters(req.PostId)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to recount post counters")
+		return nil, status.Errorf(codes.Internal, "failed to recount post counters: %v", err)
+	}
+
+	if len(ids) > 0 {
+		s.logger.WithField("post_ids", ids).Warn("repaired drifted post counters")
+	}
+
+	return &pb.RecountPostCountersResponse{
+		RepairedPostIds: ids,
+	}, nil
+}
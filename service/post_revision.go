@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	pb "github.com/mirasildev/medium_post_service/genproto/post_service"
+	"github.com/mirasildev/medium_post_service/pkg/diff"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultRevisionsLimit = 20
+	maxRevisionsLimit     = 100
+)
+
+func parsePostRevisionModel(r *repo.PostRevision) *pb.PostRevision {
+	return &pb.PostRevision{
+		Id:           r.ID,
+		PostId:       r.PostID,
+		Revision:     r.Revision,
+		Title:        r.Title,
+		Description:  r.Description,
+		UserId:       r.UserID,
+		RestoredFrom: r.RestoredFrom,
+		CreatedAt:    r.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (s *PostService) ListRevisions(ctx context.Context, req *pb.ListPostRevisionsRequest) (*pb.ListPostRevisionsResponse, error) {
+	if err := s.checkPostVisible(ctx, req.PostId); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultRevisionsLimit
+	}
+	if limit > maxRevisionsLimit {
+		limit = maxRevisionsLimit
+	}
+
+	res, err := s.storage.PostRevision().GetAll(&repo.GetAllPostRevisionsParams{
+		PostID: req.PostId,
+		Limit:  limit,
+		Page:   req.Page,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get post revisions")
+		return nil, status.Errorf(codes.Internal, "failed to get post revisions: %v", err)
+	}
+
+	response := pb.ListPostRevisionsResponse{
+		Count:     res.Count,
+		Revisions: make([]*pb.PostRevision, 0),
+	}
+
+	for _, r := range res.Revisions {
+		response.Revisions = append(response.Revisions, parsePostRevisionModel(r))
+	}
+
+	return &response, nil
+}
+
+func (s *PostService) GetRevision(ctx context.Context, req *pb.GetPostRevisionRequest) (*pb.PostRevision, error) {
+	if err := s.checkPostVisible(ctx, req.PostId); err != nil {
+		return nil, err
+	}
+
+	revision, err := s.getRevision(req.PostId, req.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePostRevisionModel(revision), nil
+}
+
+func (s *PostService) DiffRevisions(ctx context.Context, req *pb.DiffPostRevisionsRequest) (*pb.PostRevisionDiff, error) {
+	if err := s.checkPostVisible(ctx, req.PostId); err != nil {
+		return nil, err
+	}
+
+	from, err := s.getRevision(req.PostId, req.FromRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.getRevision(req.PostId, req.ToRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	title, err := diff.Lines(from.Title, to.Title)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	description, err := diff.Lines(from.Description, to.Description)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	return &pb.PostRevisionDiff{
+		FromRevision: from.Revision,
+		ToRevision:   to.Revision,
+		Title:        parseDiffLines(title),
+		Description:  parseDiffLines(description),
+	}, nil
+}
+
+func (s *PostService) RestoreRevision(ctx context.Context, req *pb.RestorePostRevisionRequest) (*pb.Post, error) {
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.storage.Post().RestoreRevision(req.PostId, userID, req.Revision)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to restore post revision")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "revision is not found or you can't update other user's post")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to restore post revision: %v", err)
+	}
+
+	return parsePostModel(post), nil
+}
+
+// checkPostVisible fails with NotFound unless the caller may read the post,
+// the history of a post is shown to the same users as the post itself.
+func (s *PostService) checkPostVisible(ctx context.Context, postID int64) error {
+	post, err := s.storage.Post().Get(postID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Errorf(codes.NotFound, "post is not found")
+		}
+		s.logger.WithError(err).Error("failed to get post")
+		return status.Errorf(codes.Internal, "failed to get post: %v", err)
+	}
+	if !canViewPost(ctx, post) {
+		return status.Errorf(codes.NotFound, "post is not found")
+	}
+
+	return nil
+}
+
+func (s *PostService) getRevision(postID int64, revision int32) (*repo.PostRevision, error) {
+	result, err := s.storage.PostRevision().Get(postID, revision)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logger.WithError(err).Error("failed to get post revision")
+			return nil, status.Errorf(codes.NotFound, "revision %d of post %d is not found", revision, postID)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get post revision: %v", err)
+	}
+
+	return result, nil
+}
+
+func parseDiffLines(lines []diff.Line) []*pb.DiffLine {
+	result := make([]*pb.DiffLine, 0, len(lines))
+	for _, l := range lines {
+		line := pb.DiffLine{Text: l.Text}
+		switch l.Op {
+		case diff.Insert:
+			line.Op = pb.DiffOp_DIFF_OP_INSERT
+		case diff.Delete:
+			line.Op = pb.DiffOp_DIFF_OP_DELETE
+		default:
+			line.Op = pb.DiffOp_DIFF_OP_EQUAL
+		}
+		result = append(result, &line)
+	}
+
+	return result
+}
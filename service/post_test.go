@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	pbu "github.com/mirasildev/medium_post_service/genproto/user_service"
+	"github.com/mirasildev/medium_post_service/pkg/auth"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanViewPost(t *testing.T) {
+	var (
+		author    = &pbu.AuthPayload{UserId: 1, UserType: auth.UserTypeUser}
+		other     = &pbu.AuthPayload{UserId: 2, UserType: auth.UserTypeUser}
+		moderator = &pbu.AuthPayload{UserId: 3, UserType: auth.UserTypeModerator}
+	)
+
+	tests := []struct {
+		status  string
+		payload *pbu.AuthPayload
+		want    bool
+	}{
+		{repo.PostStatusPublished, nil, true},
+		{repo.PostStatusPublished, other, true},
+		{repo.PostStatusDraft, nil, false},
+		{repo.PostStatusDraft, other, false},
+		{repo.PostStatusDraft, author, true},
+		{repo.PostStatusDraft, moderator, true},
+		{repo.PostStatusArchived, other, false},
+		{repo.PostStatusArchived, author, true},
+	}
+
+	for _, tc := range tests {
+		ctx := context.Background()
+		if tc.payload != nil {
+			ctx = auth.NewContext(ctx, tc.payload)
+		}
+
+		post := &repo.Post{ID: 1, UserID: 1, Status: tc.status}
+		require.Equal(t, tc.want, canViewPost(ctx, post), "%s %v", tc.status, tc.payload)
+	}
+}
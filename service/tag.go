@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	pb "github.com/mirasildev/medium_post_service/genproto/post_service"
+	"github.com/mirasildev/medium_post_service/storage"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxTagsPerPost = 5
+	maxTagLength   = 50
+
+	defaultPopularTagsDays  = 30
+	defaultPopularTagsLimit = 20
+	maxPopularTagsLimit     = 100
+)
+
+type TagService struct {
+	pb.UnimplementedTagServiceServer
+	storage storage.StorageI
+	logger  *logrus.Logger
+}
+
+func NewTagService(strg storage.StorageI, logger *logrus.Logger) *TagService {
+	return &TagService{
+		storage: strg,
+		logger:  logger,
+	}
+}
+
+func (s *TagService) SetPostTags(ctx context.Context, req *pb.SetPostTagsRequest) (*pb.PostTags, error) {
+	names, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	userID, err := actingUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.storage.Tag().SetPostTags(req.PostId, userID, names)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to set post tags")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "you can't update other user's post")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to set post tags: %v", err)
+	}
+
+	return &pb.PostTags{
+		PostId: req.PostId,
+		Tags:   parseTagModels(tags),
+	}, nil
+}
+
+func (s *TagService) GetPostTags(ctx context.Context, req *pb.GetPostTagsRequest) (*pb.PostTags, error) {
+	tags, err := s.storage.Tag().GetPostTags(req.PostId)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get post tags")
+		return nil, status.Errorf(codes.Internal, "failed to get post tags: %v", err)
+	}
+
+	return &pb.PostTags{
+		PostId: req.PostId,
+		Tags:   parseTagModels(tags),
+	}, nil
+}
+
+func (s *TagService) GetAll(ctx context.Context, req *pb.GetAllTagsRequest) (*pb.GetAllTagsResponse, error) {
+	res, err := s.storage.Tag().GetAll(&repo.GetAllTagsParams{
+		Limit:  req.Limit,
+		Page:   req.Page,
+		Search: normalizeTag(req.Search),
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get all tags")
+		return nil, status.Errorf(codes.Internal, "failed to get all tags: %v", err)
+	}
+
+	return &pb.GetAllTagsResponse{
+		Tags:  parseTagModels(res.Tags),
+		Count: res.Count,
+	}, nil
+}
+
+func (s *TagService) GetPopular(ctx context.Context, req *pb.GetPopularTagsRequest) (*pb.GetPopularTagsResponse, error) {
+	days := req.Days
+	if days <= 0 {
+		days = defaultPopularTagsDays
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultPopularTagsLimit
+	}
+	if limit > maxPopularTagsLimit {
+		limit = maxPopularTagsLimit
+	}
+
+	tags, err := s.storage.Tag().GetPopular(limit, time.Now().AddDate(0, 0, -int(days)))
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get popular tags")
+		return nil, status.Errorf(codes.Internal, "failed to get popular tags: %v", err)
+	}
+
+	return &pb.GetPopularTagsResponse{
+		Tags: parseTagModels(tags),
+	}, nil
+}
+
+func parseTagModels(tags []*repo.Tag) []*pb.Tag {
+	result := make([]*pb.Tag, 0, len(tags))
+	for _, t := range tags {
+		result = append(result, &pb.Tag{
+			Id:         t.ID,
+			Name:       t.Name,
+			PostsCount: t.PostsCount,
+			CreatedAt:  t.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return result
+}
+
+// normalizeTag lower cases the tag, drops a leading '#' and joins its words
+// with '-', so "  Machine   Learning" and "#machine-learning" are the same tag.
+func normalizeTag(name string) string {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "#")
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
+
+// normalizeTags normalizes and de-duplicates the tags of a post keeping
+// their order, empty tags are skipped.
+func normalizeTags(names []string) ([]string, error) {
+	result := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		tag := normalizeTag(name)
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, fmt.Errorf("tag %s is longer than %d characters", tag, maxTagLength)
+		}
+
+		seen[tag] = true
+		result = append(result, tag)
+	}
+
+	if len(result) > maxTagsPerPost {
+		return nil, fmt.Errorf("post can't have more than %d tags", maxTagsPerPost)
+	}
+
+	return result, nil
+}
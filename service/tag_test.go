@@ -0,0 +1,50 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "case and whitespace",
+			tags: []string{"  Go ", "Machine   Learning", "#DevOps"},
+			want: []string{"go", "machine-learning", "devops"},
+		},
+		{
+			name: "duplicates and empty tags",
+			tags: []string{"go", "GO", " ", "#", "machine learning", "machine-learning"},
+			want: []string{"go", "machine-learning"},
+		},
+		{
+			name:    "too many tags",
+			tags:    []string{"a", "b", "c", "d", "e", "f"},
+			wantErr: true,
+		},
+		{
+			name:    "too long tag",
+			tags:    []string{strings.Repeat("a", maxTagLength+1)},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := normalizeTags(tc.tags)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
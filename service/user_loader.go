@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v9"
+	pbu "github.com/mirasildev/medium_post_service/genproto/user_service"
+	grpcPkg "github.com/mirasildev/medium_post_service/pkg/grpc_client"
+	"github.com/mirasildev/medium_post_service/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const userCacheKeyPrefix = "post_service:user:"
+
+// UserLoader fetches user profiles from the user service. Every id is
+// requested once per call, at most concurrency requests run at the same
+// time and the profiles are cached for ttl.
+type UserLoader struct {
+	grpcClient  grpcPkg.GrpcClientI
+	cache       storage.InMemoryStorageI
+	ttl         time.Duration
+	concurrency int
+	logger      *logrus.Logger
+}
+
+// NewUserLoader returns a loader, cache may be nil to always ask the
+// user service.
+func NewUserLoader(grpcClient grpcPkg.GrpcClientI, cache storage.InMemoryStorageI, ttl time.Duration, concurrency int, logger *logrus.Logger) *UserLoader {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &UserLoader{
+		grpcClient:  grpcClient,
+		cache:       cache,
+		ttl:         ttl,
+		concurrency: concurrency,
+		logger:      logger,
+	}
+}
+
+// Load returns the users by their ids, the users which don't exist, e.g.
+// deleted ones, map to nil. It fails if any other lookup fails.
+func (l *UserLoader) Load(ctx context.Context, ids []int64) (map[int64]*pbu.User, error) {
+	users := make(map[int64]*pbu.User, len(ids))
+	missing := make([]int64, 0, len(ids))
+
+	for _, id := range ids {
+		if _, ok := users[id]; ok {
+			continue
+		}
+
+		// Keeps duplicates out of missing until the profile is fetched.
+		users[id] = nil
+		if user := l.getCached(id); user != nil {
+			users[id] = user
+			continue
+		}
+		missing = append(missing, id)
+	}
+
+	if len(missing) == 0 {
+		return users, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		sem      = make(chan struct{}, l.concurrency)
+	)
+
+	for _, id := range missing {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func(id int64) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			user, err := l.grpcClient.UserService().Get(ctx, &pbu.IdRequest{Id: id})
+			if status.Code(err) == codes.NotFound {
+				return
+			}
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to get user %d: %w", id, err)
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+
+			// The profile is shown to other users, the hash must not leak.
+			user.Password = ""
+			l.setCached(user)
+
+			mu.Lock()
+			users[id] = user
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// LoadOne returns a single user through the cache, nil when the user
+// doesn't exist.
+func (l *UserLoader) LoadOne(ctx context.Context, id int64) (*pbu.User, error) {
+	users, err := l.Load(ctx, []int64{id})
+	if err != nil {
+		return nil, err
+	}
+	return users[id], nil
+}
+
+func (l *UserLoader) getCached(id int64) *pbu.User {
+	if l.cache == nil {
+		return nil
+	}
+
+	value, err := l.cache.Get(userCacheKey(id))
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			l.logger.WithError(err).Warn("failed to get user from cache")
+		}
+		return nil
+	}
+
+	var user pbu.User
+	if err := proto.Unmarshal([]byte(value), &user); err != nil {
+		l.logger.WithError(err).Warn("failed to decode cached user")
+		return nil
+	}
+	return &user
+}
+
+func (l *UserLoader) setCached(user *pbu.User) {
+	if l.cache == nil {
+		return
+	}
+
+	data, err := proto.Marshal(user)
+	if err != nil {
+		l.logger.WithError(err).Warn("failed to encode user for cache")
+		return
+	}
+
+	if err := l.cache.Set(userCacheKey(user.Id), string(data), l.ttl); err != nil {
+		l.logger.WithError(err).Warn("failed to cache user")
+	}
+}
+
+func userCacheKey(id int64) string {
+	return fmt.Sprintf("%s%d", userCacheKeyPrefix, id)
+}
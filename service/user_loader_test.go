@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v9"
+	pbu "github.com/mirasildev/medium_post_service/genproto/user_service"
+	"github.com/mirasildev/medium_post_service/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeUserService struct {
+	pbu.UserServiceClient
+
+	mu       sync.Mutex
+	calls    map[int64]int
+	inFlight int32
+	maxSeen  int32
+	missing  map[int64]bool
+	failing  map[int64]bool
+}
+
+func newFakeUserService() *fakeUserService {
+	return &fakeUserService{
+		calls:   make(map[int64]int),
+		missing: make(map[int64]bool),
+		failing: make(map[int64]bool),
+	}
+}
+
+func (f *fakeUserService) Get(ctx context.Context, in *pbu.IdRequest, opts ...grpc.CallOption) (*pbu.User, error) {
+	n := atomic.AddInt32(&f.inFlight, 1)
+	defer atomic.AddInt32(&f.inFlight, -1)
+
+	f.mu.Lock()
+	f.calls[in.Id]++
+	if n > f.maxSeen {
+		f.maxSeen = n
+	}
+	missing := f.missing[in.Id]
+	failing := f.failing[in.Id]
+	f.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	if missing {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if failing {
+		return nil, status.Error(codes.Unavailable, "user service is unavailable")
+	}
+	return &pbu.User{Id: in.Id, FirstName: "user", Password: "hash"}, nil
+}
+
+type fakeGrpcClient struct {
+	userService *fakeUserService
+}
+
+func (f *fakeGrpcClient) UserService() pbu.UserServiceClient {
+	return f.userService
+}
+
+func (f *fakeGrpcClient) AuthService() pbu.AuthServiceClient {
+	return nil
+}
+
+type fakeInMemoryStorage struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func newFakeInMemoryStorage() *fakeInMemoryStorage {
+	return &fakeInMemoryStorage{values: make(map[string]string)}
+}
+
+func (f *fakeInMemoryStorage) Set(key, value string, exp time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.values[key] = value
+	return nil
+}
+
+func (f *fakeInMemoryStorage) Get(key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	value, ok := f.values[key]
+	if !ok {
+		return "", redis.Nil
+	}
+	return value, nil
+}
+
+func (f *fakeInMemoryStorage) Delete(keys ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, key := range keys {
+		delete(f.values, key)
+	}
+	return nil
+}
+
+func TestUserLoaderDeduplicates(t *testing.T) {
+	users := newFakeUserService()
+	loader := NewUserLoader(&fakeGrpcClient{userService: users}, nil, time.Minute, 4, logger.New())
+
+	result, err := loader.Load(context.Background(), []int64{1, 2, 1, 3, 2, 1})
+	require.NoError(t, err)
+	require.Len(t, result, 3)
+	for _, id := range []int64{1, 2, 3} {
+		require.Equal(t, id, result[id].Id)
+		require.Empty(t, result[id].Password)
+		require.Equal(t, 1, users.calls[id])
+	}
+}
+
+func TestUserLoaderBoundsConcurrency(t *testing.T) {
+	users := newFakeUserService()
+	loader := NewUserLoader(&fakeGrpcClient{userService: users}, nil, time.Minute, 3, logger.New())
+
+	ids := make([]int64, 0, 20)
+	for i := int64(1); i <= 20; i++ {
+		ids = append(ids, i)
+	}
+
+	result, err := loader.Load(context.Background(), ids)
+	require.NoError(t, err)
+	require.Len(t, result, 20)
+	require.LessOrEqual(t, users.maxSeen, int32(3))
+}
+
+func TestUserLoaderCachesProfiles(t *testing.T) {
+	users := newFakeUserService()
+	cache := newFakeInMemoryStorage()
+	loader := NewUserLoader(&fakeGrpcClient{userService: users}, cache, time.Minute, 4, logger.New())
+
+	_, err := loader.Load(context.Background(), []int64{1, 2})
+	require.NoError(t, err)
+
+	result, err := loader.Load(context.Background(), []int64{1, 2, 3})
+	require.NoError(t, err)
+	require.Len(t, result, 3)
+	require.Equal(t, "user", result[1].FirstName)
+	require.Equal(t, 1, users.calls[1])
+	require.Equal(t, 1, users.calls[2])
+	require.Equal(t, 1, users.calls[3])
+}
+
+func TestUserLoaderSkipsMissingUsers(t *testing.T) {
+	users := newFakeUserService()
+	users.missing[2] = true
+	loader := NewUserLoader(&fakeGrpcClient{userService: users}, nil, time.Minute, 2, logger.New())
+
+	result, err := loader.Load(context.Background(), []int64{1, 2, 3})
+	require.NoError(t, err)
+	require.NotNil(t, result[1])
+	require.Nil(t, result[2])
+	require.NotNil(t, result[3])
+
+	user, err := loader.LoadOne(context.Background(), 2)
+	require.NoError(t, err)
+	require.Nil(t, user)
+}
+
+func TestUserLoaderFails(t *testing.T) {
+	users := newFakeUserService()
+	users.failing[2] = true
+	loader := NewUserLoader(&fakeGrpcClient{userService: users}, nil, time.Minute, 2, logger.New())
+
+	_, err := loader.Load(context.Background(), []int64{1, 2, 3})
+	require.Error(t, err)
+	require.Equal(t, codes.Unavailable, status.Code(errors.Unwrap(err)))
+}
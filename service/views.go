@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/mirasildev/medium_post_service/pkg/auth"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// recordView counts a view of the post. A failure is only logged, the
+// post is returned anyway.
+func (s *PostService) recordView(ctx context.Context, postID int64) {
+	if s.views == nil {
+		if err := s.storage.Post().AddViews(map[int64]int64{postID: 1}); err != nil {
+			s.logger.WithError(err).Error("failed to count post view")
+		}
+		return
+	}
+
+	viewer := viewerKey(ctx, s.trustedProxies)
+	if viewer == "" {
+		return
+	}
+
+	if _, err := s.views.Record(postID, viewer, s.viewWindow); err != nil {
+		s.logger.WithError(err).Error("failed to record post view")
+	}
+}
+
+// viewerKey identifies the viewer by their user id or, for anonymous
+// readers, by a hash of their IP so the address itself is not stored.
+func viewerKey(ctx context.Context, trustedProxies []*net.IPNet) string {
+	if payload, ok := auth.FromContext(ctx); ok {
+		return "u:" + strconv.FormatInt(payload.UserId, 10)
+	}
+
+	ip := clientIP(ctx, trustedProxies)
+	if ip == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(ip))
+	return "ip:" + hex.EncodeToString(sum[:16])
+}
+
+// clientIP returns the address of the connection. The addresses forwarded
+// in the headers are believed only when the connection comes from one of
+// the trusted proxies, anyone else could make them up.
+func clientIP(ctx context.Context, trustedProxies []*net.IPNet) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	if !isTrustedProxy(host, trustedProxies) {
+		return host
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return host
+	}
+
+	// Every proxy appends the address it got the request from, the last
+	// address not added by a trusted proxy is the client.
+	if values := md.Get("x-forwarded-for"); len(values) > 0 {
+		hops := strings.Split(strings.Join(values, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if hop == "" {
+				continue
+			}
+			if !isTrustedProxy(hop, trustedProxies) || i == 0 {
+				return hop
+			}
+		}
+	}
+	if values := md.Get("x-real-ip"); len(values) > 0 && strings.TrimSpace(values[0]) != "" {
+		return strings.TrimSpace(values[0])
+	}
+
+	return host
+}
+
+func isTrustedProxy(addr string, trustedProxies []*net.IPNet) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTrustedProxies parses the addresses of the proxies which forward
+// the IPs of the clients, every one is either an IP or a CIDR.
+func ParseTrustedProxies(addrs []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(addrs))
+	for _, addr := range addrs {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+
+		if !strings.Contains(addr, "/") {
+			ip := net.ParseIP(addr)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", addr)
+			}
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", addr, err)
+		}
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	pbu "github.com/mirasildev/medium_post_service/genproto/user_service"
+	"github.com/mirasildev/medium_post_service/pkg/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestViewerKey(t *testing.T) {
+	withPeer := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000},
+		})
+	}
+
+	trusted, err := ParseTrustedProxies([]string{"10.0.0.9", "192.168.0.0/16"})
+	require.NoError(t, err)
+
+	user := auth.NewContext(withPeer("10.0.0.1"), &pbu.AuthPayload{UserId: 5})
+	require.Equal(t, "u:5", viewerKey(user, trusted))
+
+	first := viewerKey(withPeer("10.0.0.1"), trusted)
+	require.Regexp(t, "^ip:[0-9a-f]{32}$", first)
+	require.NotContains(t, first, "10.0.0.1")
+	require.Equal(t, first, viewerKey(withPeer("10.0.0.1"), trusted))
+	require.NotEqual(t, first, viewerKey(withPeer("10.0.0.2"), trusted))
+
+	forwarded := metadata.NewIncomingContext(withPeer("10.0.0.9"), metadata.Pairs("x-forwarded-for", "10.0.0.3, 10.0.0.1, 192.168.1.1"))
+	require.Equal(t, first, viewerKey(forwarded, trusted))
+
+	// The headers of untrusted peers are ignored.
+	spoofed := metadata.NewIncomingContext(withPeer("10.0.0.2"), metadata.Pairs("x-forwarded-for", "10.0.0.1", "x-real-ip", "10.0.0.1"))
+	require.Equal(t, viewerKey(withPeer("10.0.0.2"), trusted), viewerKey(spoofed, trusted))
+	require.Equal(t, viewerKey(withPeer("10.0.0.9"), nil), viewerKey(forwarded, nil))
+
+	realIP := metadata.NewIncomingContext(withPeer("192.168.3.4"), metadata.Pairs("x-real-ip", "10.0.0.1"))
+	require.Equal(t, first, viewerKey(realIP, trusted))
+
+	require.Empty(t, viewerKey(context.Background(), trusted))
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	networks, err := ParseTrustedProxies([]string{"", " 10.0.0.1 ", "fd00::/8"})
+	require.NoError(t, err)
+	require.Len(t, networks, 2)
+
+	_, err = ParseTrustedProxies([]string{"proxy"})
+	require.Error(t, err)
+}
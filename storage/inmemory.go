@@ -10,6 +10,7 @@ import (
 type InMemoryStorageI interface {
 	Set(key, value string, exp time.Duration) error
 	Get(key string) (string, error)
+	Delete(keys ...string) error
 }
 
 type storageRedis struct {
@@ -37,3 +38,7 @@ func (r *storageRedis) Get(key string) (string, error) {
 	}
 	return val, nil
 }
+
+func (r *storageRedis) Delete(keys ...string) error {
+	return r.client.Del(context.Background(), keys...).Err()
+}
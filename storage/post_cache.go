@@ -0,0 +1,227 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v9"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/sirupsen/logrus"
+)
+
+const postCacheKeyPrefix = "post_service:post:"
+
+type storageCached struct {
+	StorageI
+	postRepo repo.PostStorageI
+}
+
+// NewCachedStorage serves Post().Get from the in-memory storage and drops
+// the cached post on every change made through strg. Views, reactions and
+// comments counted while a post is cached show up after ttl, so do the
+// categories of posts moved by deleting or merging categories.
+func NewCachedStorage(strg StorageI, inMemory InMemoryStorageI, ttl time.Duration, logger *logrus.Logger) StorageI {
+	return &storageCached{
+		StorageI: strg,
+		postRepo: &cachedPostRepo{
+			PostStorageI: strg.Post(),
+			cache:        inMemory,
+			ttl:          ttl,
+			logger:       logger,
+		},
+	}
+}
+
+func (s *storageCached) Post() repo.PostStorageI {
+	return s.postRepo
+}
+
+// cachedPostRepo is a read-through cache in front of another post repo.
+// The methods which are not overridden don't touch single posts.
+type cachedPostRepo struct {
+	repo.PostStorageI
+	cache  InMemoryStorageI
+	ttl    time.Duration
+	logger *logrus.Logger
+	flight flightGroup
+}
+
+func (r *cachedPostRepo) Get(id int64) (*repo.Post, error) {
+	key := postCacheKey(id)
+
+	if value, err := r.cache.Get(key); err == nil {
+		var post repo.Post
+		err = json.Unmarshal([]byte(value), &post)
+		if err == nil {
+			return &post, nil
+		}
+		r.logger.WithError(err).Warn("failed to decode cached post")
+	} else if !errors.Is(err, redis.Nil) {
+		r.logger.WithError(err).Warn("failed to get post from cache")
+	}
+
+	// Concurrent misses of one post share a single query.
+	value, err := r.flight.Do(key, func() (interface{}, error) {
+		// The previous flight may have filled the cache since the miss.
+		if value, err := r.cache.Get(key); err == nil {
+			return []byte(value), nil
+		}
+
+		post, err := r.PostStorageI.Get(id)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(post)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.cache.Set(key, string(data), r.ttl); err != nil {
+			r.logger.WithError(err).Warn("failed to cache post")
+		}
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Every caller gets its own copy of the shared result.
+	var post repo.Post
+	if err := json.Unmarshal(value.([]byte), &post); err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+func (r *cachedPostRepo) UpdatePost(p *repo.Post) (*repo.Post, error) {
+	post, err := r.PostStorageI.UpdatePost(p)
+	r.invalidate(p.ID)
+	return post, err
+}
+
+func (r *cachedPostRepo) DeletePost(id, userID int64) error {
+	err := r.PostStorageI.DeletePost(id, userID)
+	r.invalidate(id)
+	return err
+}
+
+func (r *cachedPostRepo) RestorePost(id, userID int64) (*repo.Post, error) {
+	post, err := r.PostStorageI.RestorePost(id, userID)
+	r.invalidate(id)
+	return post, err
+}
+
+func (r *cachedPostRepo) PurgeDeleted(before time.Time, limit int) ([]int64, error) {
+	ids, err := r.PostStorageI.PurgeDeleted(before, limit)
+	r.invalidate(ids...)
+	return ids, err
+}
+
+func (r *cachedPostRepo) UpdateStatus(id, userID int64, status string) (*repo.Post, error) {
+	post, err := r.PostStorageI.UpdateStatus(id, userID, status)
+	r.invalidate(id)
+	return post, err
+}
+
+func (r *cachedPostRepo) SchedulePublish(id, userID int64, publishAt time.Time) (*repo.Post, error) {
+	post, err := r.PostStorageI.SchedulePublish(id, userID, publishAt)
+	r.invalidate(id)
+	return post, err
+}
+
+func (r *cachedPostRepo) ReschedulePublish(id, userID int64, publishAt time.Time) (*repo.Post, error) {
+	post, err := r.PostStorageI.ReschedulePublish(id, userID, publishAt)
+	r.invalidate(id)
+	return post, err
+}
+
+func (r *cachedPostRepo) CancelScheduledPublish(id, userID int64) (*repo.Post, error) {
+	post, err := r.PostStorageI.CancelScheduledPublish(id, userID)
+	r.invalidate(id)
+	return post, err
+}
+
+func (r *cachedPostRepo) PublishDue(now time.Time, limit int) ([]*repo.Post, error) {
+	posts, err := r.PostStorageI.PublishDue(now, limit)
+	ids := make([]int64, 0, len(posts))
+	for _, p := range posts {
+		ids = append(ids, p.ID)
+	}
+	r.invalidate(ids...)
+	return posts, err
+}
+
+func (r *cachedPostRepo) RestoreRevision(postID, userID int64, revision int32) (*repo.Post, error) {
+	post, err := r.PostStorageI.RestoreRevision(postID, userID, revision)
+	r.invalidate(postID)
+	return post, err
+}
+
+func (r *cachedPostRepo) RecountCounters(postID int64) ([]int64, error) {
+	ids, err := r.PostStorageI.RecountCounters(postID)
+	r.invalidate(ids...)
+	return ids, err
+}
+
+// invalidate runs after failed writes too, dropping a key is always safe.
+func (r *cachedPostRepo) invalidate(ids ...int64) {
+	if len(ids) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, postCacheKey(id))
+	}
+
+	if err := r.cache.Delete(keys...); err != nil {
+		r.logger.WithError(err).Error("failed to invalidate cached posts")
+	}
+}
+
+func postCacheKey(id int64) string {
+	return fmt.Sprintf("%s%d", postCacheKeyPrefix, id)
+}
+
+// flightGroup runs one call per key at a time and hands its result to
+// every caller which asked for the key meanwhile.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	wg    sync.WaitGroup
+	value interface{}
+	err   error
+}
+
+func (g *flightGroup) Do(key string, fn func() (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.value, c.err
+	}
+
+	c := &flightCall{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		c.wg.Done()
+	}()
+
+	c.value, c.err = fn()
+	return c.value, c.err
+}
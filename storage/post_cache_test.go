@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v9"
+	"github.com/mirasildev/medium_post_service/pkg/logger"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePostRepo struct {
+	repo.PostStorageI
+	gets  int32
+	posts map[int64]*repo.Post
+}
+
+func (f *fakePostRepo) Get(id int64) (*repo.Post, error) {
+	atomic.AddInt32(&f.gets, 1)
+	time.Sleep(10 * time.Millisecond)
+
+	p, ok := f.posts[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	copied := *p
+	return &copied, nil
+}
+
+func (f *fakePostRepo) UpdatePost(p *repo.Post) (*repo.Post, error) {
+	f.posts[p.ID].Title = p.Title
+	return f.posts[p.ID], nil
+}
+
+type fakeStorage struct {
+	StorageI
+	postRepo *fakePostRepo
+}
+
+func (f *fakeStorage) Post() repo.PostStorageI {
+	return f.postRepo
+}
+
+type fakeInMemoryStorage struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (f *fakeInMemoryStorage) Set(key, value string, exp time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.values[key] = value
+	return nil
+}
+
+func (f *fakeInMemoryStorage) Get(key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	value, ok := f.values[key]
+	if !ok {
+		return "", redis.Nil
+	}
+	return value, nil
+}
+
+func (f *fakeInMemoryStorage) Delete(keys ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, key := range keys {
+		delete(f.values, key)
+	}
+	return nil
+}
+
+func newCachedTestStorage() (StorageI, *fakePostRepo) {
+	posts := &fakePostRepo{
+		posts: map[int64]*repo.Post{
+			1: {ID: 1, Title: "first", CreatedAt: time.Now().UTC()},
+		},
+	}
+	strg := NewCachedStorage(
+		&fakeStorage{postRepo: posts},
+		&fakeInMemoryStorage{values: make(map[string]string)},
+		time.Minute,
+		logger.New(),
+	)
+	return strg, posts
+}
+
+func TestCachedPostGet(t *testing.T) {
+	strg, posts := newCachedTestStorage()
+
+	for i := 0; i < 3; i++ {
+		p, err := strg.Post().Get(1)
+		require.NoError(t, err)
+		require.Equal(t, "first", p.Title)
+	}
+	require.Equal(t, int32(1), posts.gets)
+
+	_, err := strg.Post().Get(2)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCachedPostInvalidation(t *testing.T) {
+	strg, posts := newCachedTestStorage()
+
+	_, err := strg.Post().Get(1)
+	require.NoError(t, err)
+
+	_, err = strg.Post().UpdatePost(&repo.Post{ID: 1, Title: "second"})
+	require.NoError(t, err)
+
+	p, err := strg.Post().Get(1)
+	require.NoError(t, err)
+	require.Equal(t, "second", p.Title)
+	require.Equal(t, int32(2), posts.gets)
+}
+
+func TestCachedPostSingleFlight(t *testing.T) {
+	strg, posts := newCachedTestStorage()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p, err := strg.Post().Get(1)
+			if assert.NoError(t, err) {
+				assert.Equal(t, int64(1), p.ID)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(1), posts.gets)
+}
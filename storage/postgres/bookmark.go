@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mirasildev/medium_post_service/storage/postgres/internal/qb"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+)
+
+type bookmarkRepo struct {
+	db *sqlx.DB
+}
+
+func NewBookmark(db *sqlx.DB) repo.BookmarkStorageI {
+	return &bookmarkRepo{
+		db: db,
+	}
+}
+
+var bookmarkSortColumns = map[string]string{
+	"created_at": "b.created_at",
+	"id":         "b.id",
+}
+
+func (br *bookmarkRepo) Add(userID, postID int64) (*repo.Bookmark, error) {
+	var result repo.Bookmark
+
+	// The no-op update returns the stored bookmark of a repeated call.
+	query := `
+		INSERT INTO bookmarks(user_id, post_id)
+		SELECT $1, id FROM posts WHERE id=$2 AND status=$3 AND deleted_at IS NULL
+		ON CONFLICT (user_id, post_id) DO UPDATE SET user_id=EXCLUDED.user_id
+		RETURNING id, user_id, post_id, created_at
+	`
+
+	err := br.db.QueryRow(query, userID, postID, repo.PostStatusPublished).Scan(
+		&result.ID,
+		&result.UserID,
+		&result.PostID,
+		&result.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (br *bookmarkRepo) Remove(userID, postID int64) error {
+	_, err := br.db.Exec("DELETE FROM bookmarks WHERE user_id=$1 AND post_id=$2", userID, postID)
+	return err
+}
+
+func (br *bookmarkRepo) GetAll(params *repo.GetBookmarksParams) (*repo.GetBookmarksResult, error) {
+	result := repo.GetBookmarksResult{
+		Bookmarks: make([]*repo.Bookmark, 0),
+	}
+
+	b := qb.New()
+	b.Where("b.user_id=?", params.UserID)
+	b.Where("posts.status=?", repo.PostStatusPublished)
+	b.Where("posts.deleted_at IS NULL")
+	for _, column := range []string{"created_at", "id"} {
+		if err := b.OrderBy(bookmarkSortColumns, column, "desc"); err != nil {
+			return nil, err
+		}
+	}
+
+	from := `
+		FROM bookmarks b
+		INNER JOIN posts ON posts.id=b.post_id`
+
+	// The count ignores the keyset so it is built before it is applied.
+	queryCount, countArgs := b.BuildCount("SELECT count(1)" + from)
+
+	applyKeyset(b, "b.created_at, b.id", "desc", keysetArgs(params.After), params.Limit, 1)
+
+	query, args := b.Build(`
+		SELECT
+			` + prefixColumns("posts", postColumns) + `,
+			b.id,
+			b.user_id,
+			b.created_at` + from)
+
+	rows, err := br.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var bm repo.Bookmark
+		bm.Post, err = scanPost(rows, &bm.ID, &bm.UserID, &bm.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		bm.PostID = bm.Post.ID
+		result.Bookmarks = append(result.Bookmarks, &bm)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if params.Limit > 0 && len(result.Bookmarks) > int(params.Limit) {
+		result.Bookmarks = result.Bookmarks[:params.Limit]
+		last := result.Bookmarks[len(result.Bookmarks)-1]
+		result.Next = &repo.Keyset{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		}
+	}
+
+	err = br.db.QueryRow(queryCount, countArgs...).Scan(&result.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (br *bookmarkRepo) GetBookmarked(userID int64, postIDs []int64) (map[int64]bool, error) {
+	result := make(map[int64]bool, len(postIDs))
+	if len(postIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := br.db.Query(
+		"SELECT post_id FROM bookmarks WHERE user_id=$1 AND post_id=ANY($2::INTEGER[])",
+		userID, pq.Array(postIDs),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID int64
+		if err := rows.Scan(&postID); err != nil {
+			return nil, err
+		}
+		result[postID] = true
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,147 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookmarks(t *testing.T) {
+	const reader = 501
+	category := createCategory(t)
+	first := createPublishedPost(t, 502, category.ID)
+	second := createPublishedPost(t, 502, category.ID)
+
+	added, err := strg.Bookmark().Add(reader, first.ID)
+	require.NoError(t, err)
+	again, err := strg.Bookmark().Add(reader, first.ID)
+	require.NoError(t, err)
+	require.Equal(t, added.ID, again.ID)
+
+	_, err = strg.Bookmark().Add(reader, second.ID)
+	require.NoError(t, err)
+
+	_, err = strg.Bookmark().Add(reader, createPost(t).ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	params := &repo.GetBookmarksParams{UserID: reader, Limit: 1}
+	page, err := strg.Bookmark().GetAll(params)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), page.Count)
+	require.Len(t, page.Bookmarks, 1)
+	require.Equal(t, second.ID, page.Bookmarks[0].PostID)
+	require.Equal(t, second.Title, page.Bookmarks[0].Post.Title)
+	require.NotNil(t, page.Next)
+
+	params.After = page.Next
+	page, err = strg.Bookmark().GetAll(params)
+	require.NoError(t, err)
+	require.Len(t, page.Bookmarks, 1)
+	require.Equal(t, first.ID, page.Bookmarks[0].PostID)
+	require.Nil(t, page.Next)
+
+	bookmarked, err := strg.Bookmark().GetBookmarked(reader, []int64{first.ID, second.ID, -1})
+	require.NoError(t, err)
+	require.Equal(t, map[int64]bool{first.ID: true, second.ID: true}, bookmarked)
+
+	require.NoError(t, strg.Bookmark().Remove(reader, first.ID))
+	require.NoError(t, strg.Bookmark().Remove(reader, first.ID))
+
+	_, err = db.Exec("DELETE FROM posts WHERE id=$1", second.ID)
+	require.NoError(t, err)
+
+	page, err = strg.Bookmark().GetAll(&repo.GetBookmarksParams{UserID: reader, Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, page.Bookmarks)
+}
+
+func TestReadingLists(t *testing.T) {
+	const (
+		owner = 511
+		other = 512
+	)
+	category := createCategory(t)
+	first := createPublishedPost(t, 513, category.ID)
+	second := createPublishedPost(t, 513, category.ID)
+
+	list, err := strg.ReadingList().Create(&repo.ReadingList{UserID: owner, Name: "later"})
+	require.NoError(t, err)
+
+	_, err = strg.ReadingList().Create(&repo.ReadingList{UserID: owner, Name: "later"})
+	require.ErrorIs(t, err, repo.ErrAlreadyExists)
+
+	list, err = strg.ReadingList().Update(&repo.ReadingList{ID: list.ID, UserID: owner, Name: "soon", IsPublic: true})
+	require.NoError(t, err)
+	require.Equal(t, "soon", list.Name)
+	require.True(t, list.IsPublic)
+
+	require.NoError(t, strg.ReadingList().AddPost(list.ID, owner, first.ID))
+	require.NoError(t, strg.ReadingList().AddPost(list.ID, owner, second.ID))
+	require.NoError(t, strg.ReadingList().AddPost(list.ID, owner, second.ID))
+	require.ErrorIs(t, strg.ReadingList().AddPost(list.ID, owner, createPost(t).ID), sql.ErrNoRows)
+	require.ErrorIs(t, strg.ReadingList().AddPost(list.ID, other, first.ID), repo.ErrPermissionDenied)
+
+	posts, err := strg.ReadingList().GetPosts(list.ID)
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+	require.Equal(t, first.ID, posts[0].ID)
+
+	require.ErrorIs(t, strg.ReadingList().Reorder(list.ID, owner, []int64{second.ID}), repo.ErrInvalidArgument)
+	require.ErrorIs(t, strg.ReadingList().Reorder(list.ID, owner, []int64{second.ID, second.ID}), repo.ErrInvalidArgument)
+	require.NoError(t, strg.ReadingList().Reorder(list.ID, owner, []int64{second.ID, first.ID}))
+
+	posts, err = strg.ReadingList().GetPosts(list.ID)
+	require.NoError(t, err)
+	require.Equal(t, second.ID, posts[0].ID)
+	require.Equal(t, first.ID, posts[1].ID)
+
+	require.NoError(t, strg.ReadingList().RemovePost(list.ID, owner, second.ID))
+	list, err = strg.ReadingList().Get(list.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), list.PostsCount)
+
+	private, err := strg.ReadingList().Create(&repo.ReadingList{UserID: owner, Name: "private"})
+	require.NoError(t, err)
+
+	lists, err := strg.ReadingList().GetAll(owner, false)
+	require.NoError(t, err)
+	require.Len(t, lists, 1)
+	lists, err = strg.ReadingList().GetAll(owner, true)
+	require.NoError(t, err)
+	require.Len(t, lists, 2)
+
+	require.ErrorIs(t, strg.ReadingList().Delete(private.ID, other), repo.ErrPermissionDenied)
+	require.NoError(t, strg.ReadingList().Delete(private.ID, owner))
+	_, err = strg.ReadingList().Get(private.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestReorderReadingListWithHiddenPosts(t *testing.T) {
+	const owner = 521
+	category := createCategory(t)
+	first := createPublishedPost(t, owner, category.ID)
+	hidden := createPublishedPost(t, owner, category.ID)
+	second := createPublishedPost(t, owner, category.ID)
+
+	list, err := strg.ReadingList().Create(&repo.ReadingList{UserID: owner, Name: "hidden"})
+	require.NoError(t, err)
+	for _, p := range []*repo.Post{first, hidden, second} {
+		require.NoError(t, strg.ReadingList().AddPost(list.ID, owner, p.ID))
+	}
+	require.NoError(t, strg.Post().DeletePost(hidden.ID, owner))
+
+	require.ErrorIs(t, strg.ReadingList().Reorder(list.ID, owner, []int64{second.ID, hidden.ID, first.ID}), repo.ErrInvalidArgument)
+	require.NoError(t, strg.ReadingList().Reorder(list.ID, owner, []int64{second.ID, first.ID}))
+
+	_, err = strg.Post().RestorePost(hidden.ID, owner)
+	require.NoError(t, err)
+
+	posts, err := strg.ReadingList().GetPosts(list.ID)
+	require.NoError(t, err)
+	require.Len(t, posts, 3)
+	require.Equal(t, second.ID, posts[0].ID)
+	require.Equal(t, first.ID, posts[1].ID)
+	require.Equal(t, hidden.ID, posts[2].ID)
+}
@@ -5,6 +5,10 @@ import (
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mirasildev/medium_post_service/pkg/slug"
+	"github.com/mirasildev/medium_post_service/pkg/utils"
+	"github.com/mirasildev/medium_post_service/storage/postgres/internal/qb"
 	"github.com/mirasildev/medium_post_service/storage/repo"
 )
 
@@ -18,22 +22,86 @@ func NewCategory(db *sqlx.DB) repo.CategoryStorageI {
 	}
 }
 
+const categoryColumns = `
+			id,
+			title,
+			slug,
+			parent_id,
+			created_at`
+
+// scanCategory scans a row selected with categoryColumns, extra holds the
+// destinations of the columns selected after them.
+func scanCategory(row scanner, extra ...interface{}) (*repo.Category, error) {
+	var (
+		result   repo.Category
+		parentID sql.NullInt64
+	)
+
+	dest := []interface{}{
+		&result.ID,
+		&result.Title,
+		&result.Slug,
+		&parentID,
+		&result.CreatedAt,
+	}
+
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	result.ParentID = parentID.Int64
+
+	return &result, nil
+}
+
 func (cr *categoryRepo) Create(category *repo.Category) (*repo.Category, error) {
+	tx, err := cr.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// The lock keeps the slug free and the parent's depth unchanged until
+	// the category is stored.
+	if err := lockCategoryTree(tx); err != nil {
+		return nil, err
+	}
+
+	if category.ParentID != 0 {
+		if err := checkCategoryParent(tx, 0, category.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	category.Slug, err = uniqueCategorySlug(tx, category.Title)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		INSERT INTO categories(title) VALUES($1)
+		INSERT INTO categories(title, slug, parent_id) VALUES($1, $2, $3)
 		RETURNING id, created_at
 	`
 
-	row := cr.db.QueryRow(
+	row := tx.QueryRow(
 		query,
 		category.Title,
+		category.Slug,
+		utils.NullInt64(category.ParentID),
 	)
 
-	err := row.Scan(
+	err = row.Scan(
 		&category.ID,
 		&category.CreatedAt,
 	)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, repo.ErrAlreadyExists
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
 
@@ -41,55 +109,83 @@ func (cr *categoryRepo) Create(category *repo.Category) (*repo.Category, error)
 }
 
 func (cr *categoryRepo) Get(id int64) (*repo.Category, error) {
-	var result repo.Category
-
 	query := `
 		SELECT
-			id,
-			title,
-			created_at
+			` + categoryColumns + `
 		FROM categories
 		WHERE id=$1
 	`
 
-	row := cr.db.QueryRow(query, id)
-	err := row.Scan(
-		&result.ID,
-		&result.Title,
-		&result.CreatedAt,
-	)
-	if err != nil {
-		return nil, err
-	}
+	return scanCategory(cr.db.QueryRow(query, id))
+}
 
-	return &result, nil
+func (cr *categoryRepo) GetBySlug(slug string) (*repo.Category, error) {
+	query := `
+		SELECT
+			` + categoryColumns + `
+		FROM categories
+		WHERE slug=$1
+	`
+
+	return scanCategory(cr.db.QueryRow(query, slug))
 }
 
+var (
+	categorySortColumns = map[string]string{
+		"created_at":       "c.created_at",
+		"followers_count":  "COALESCE(s.followers_count, 0)",
+		"posts_count":      "COALESCE(s.posts_count, 0)",
+		"last_post_at":     "COALESCE(s.last_post_at, 'epoch'::TIMESTAMPTZ)",
+		"weekly_followers": "COALESCE(s.weekly_followers, 0)",
+		"id":               "c.id",
+	}
+	categorySorts = map[string]string{
+		repo.CategorySortNewest:         "created_at",
+		repo.CategorySortPopular:        "followers_count",
+		repo.CategorySortMostPosts:      "posts_count",
+		repo.CategorySortRecentlyActive: "last_post_at",
+		repo.CategorySortGrowing:        "weekly_followers",
+	}
+)
+
 func (cr *categoryRepo) GetAll(params *repo.GetAllCategoriesParams) (*repo.GetAllCategoriesResult, error) {
 	result := repo.GetAllCategoriesResult{
 		Categories: make([]*repo.Category, 0),
 	}
 
-	offset := (params.Page - 1) * params.Limit
-
-	limit := fmt.Sprintf(" LIMIT %d OFFSET %d ", params.Limit, offset)
-
-	filter := ""
+	b := qb.New()
 	if params.Search != "" {
-		filter += " WHERE title ilike '%" + params.Search + "%' "
+		b.Where("c.title ilike '%' || ? || '%'", params.Search)
 	}
 
-	query := `
+	sort := params.Sort
+	if sort == "" {
+		sort = repo.CategorySortNewest
+	}
+	column, ok := categorySorts[sort]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown sort %q", repo.ErrInvalidArgument, sort)
+	}
+	for _, column := range []string{column, "id"} {
+		if err := b.OrderBy(categorySortColumns, column, "desc"); err != nil {
+			return nil, err
+		}
+	}
+	b.Page(params.Limit, params.Page)
+
+	// The categories created since the last refresh have no stats yet.
+	query, args := b.Build(`
 		SELECT
-			id, 
-			title, 
-			created_at
-		FROM categories
-		` + filter + `
-		ORDER BY created_at desc
-		` + limit
+			` + prefixColumns("c", categoryColumns) + `,
+			COALESCE(s.posts_count, 0),
+			s.last_post_at,
+			COALESCE(s.followers_count, 0),
+			COALESCE(s.weekly_posts, 0),
+			COALESCE(s.weekly_followers, 0)
+		FROM categories c
+		LEFT JOIN category_stats s ON s.category_id=c.id`)
 
-	rows, err := cr.db.Query(query)
+	rows, err := cr.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -97,22 +193,30 @@ func (cr *categoryRepo) GetAll(params *repo.GetAllCategoriesParams) (*repo.GetAl
 	defer rows.Close()
 
 	for rows.Next() {
-		var c repo.Category
+		var (
+			stats      repo.CategoryStats
+			lastPostAt sql.NullTime
+		)
 
-		err := rows.Scan(
-			&c.ID,
-			&c.Title,
-			&c.CreatedAt,
+		c, err := scanCategory(
+			rows,
+			&stats.PostsCount,
+			&lastPostAt,
+			&stats.FollowersCount,
+			&stats.WeeklyPosts,
+			&stats.WeeklyFollowers,
 		)
 		if err != nil {
 			return nil, err
 		}
+		stats.LastPostAt = lastPostAt.Time
+		c.Stats = &stats
 
-		result.Categories = append(result.Categories, &c)
+		result.Categories = append(result.Categories, c)
 	}
 
-	queryCount := `SELECT count(1) FROM categories ` + filter
-	err = cr.db.QueryRow(queryCount).Scan(&result.Count)
+	queryCount, args := b.BuildCount(`SELECT count(1) FROM categories c`)
+	err = cr.db.QueryRow(queryCount, args...).Scan(&result.Count)
 	if err != nil {
 		return nil, err
 	}
@@ -120,44 +224,378 @@ func (cr *categoryRepo) GetAll(params *repo.GetAllCategoriesParams) (*repo.GetAl
 	return &result, nil
 }
 
+func (cr *categoryRepo) RefreshStats() error {
+	_, err := cr.db.Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY category_stats")
+	return err
+}
+
+func (cr *categoryRepo) GetTree(rootID int64) ([]*repo.Category, error) {
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT
+				` + categoryColumns + `,
+				0 AS depth
+			FROM categories
+			WHERE ($1::INTEGER=0 AND parent_id IS NULL) OR id=$1
+			UNION ALL
+			SELECT
+				` + prefixColumns("c", categoryColumns) + `,
+				t.depth+1
+			FROM categories c
+			INNER JOIN tree t ON c.parent_id=t.id
+		)
+		SELECT
+			` + categoryColumns + `
+		FROM tree
+		ORDER BY depth, title, id
+	`
+
+	rows, err := cr.db.Query(query, rootID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*repo.Category, 0)
+	for rows.Next() {
+		c, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if rootID != 0 && len(result) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return result, nil
+}
+
 func (cr *categoryRepo) Update(ctr *repo.Category) (*repo.Category, error) {
 	query := `
 		UPDATE categories SET title=$1 WHERE id=$2
-		RETURNING id, title, created_at
-	`
-	var result repo.Category
-	err := cr.db.QueryRow(
+		RETURNING ` + categoryColumns
+
+	result, err := scanCategory(cr.db.QueryRow(
 		query,
 		ctr.Title,
 		ctr.ID,
-	).Scan(
-		&result.ID,
-		&result.Title,
-		&result.CreatedAt,
-	)
+	))
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, repo.ErrAlreadyExists
+		}
 		return nil, err
 	}
 
-	return &result, nil
+	return result, nil
+}
+
+func (cr *categoryRepo) Move(id, parentID int64) (*repo.Category, error) {
+	tx, err := cr.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockCategoryTree(tx); err != nil {
+		return nil, err
+	}
+
+	if parentID != 0 {
+		if err := checkCategoryParent(tx, id, parentID); err != nil {
+			return nil, err
+		}
+	}
+
+	query := `
+		UPDATE categories SET parent_id=$1 WHERE id=$2
+		RETURNING ` + categoryColumns
+
+	result, err := scanCategory(tx.QueryRow(query, utils.NullInt64(parentID), id))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
-func (cr *categoryRepo) Delete(id int64) error {
+func (cr *categoryRepo) Delete(id, targetID int64) error {
+	tx, err := cr.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockCategoryTree(tx); err != nil {
+		return err
+	}
+
+	if targetID != 0 {
+		if err := mergeCategories(tx, targetID, []int64{id}); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	// The lock keeps posts from being added to the category meanwhile.
+	if err := lockCategories(tx, []int64{id}); err != nil {
+		return err
+	}
 
-	query := "DELETE FROM categories WHERE id=$1"
-	result, err := cr.db.Exec(query, id)
+	var postsCount, childrenCount int64
+	err = tx.QueryRow(`
+		SELECT
+			(SELECT count(1) FROM posts WHERE category_id=$1),
+			(SELECT count(1) FROM categories WHERE parent_id=$1)
+	`, id).Scan(&postsCount, &childrenCount)
 	if err != nil {
 		return err
 	}
+	if postsCount > 0 {
+		return fmt.Errorf("%w: the category has %d posts, they must be moved to another category", repo.ErrFailedPrecondition, postsCount)
+	}
+	if childrenCount > 0 {
+		return fmt.Errorf("%w: the category has %d subcategories, they must be moved to another category", repo.ErrFailedPrecondition, childrenCount)
+	}
+
+	if _, err := tx.Exec("DELETE FROM categories WHERE id=$1", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
 
-	rowsCount, err := result.RowsAffected()
+func (cr *categoryRepo) Merge(targetID int64, sourceIDs []int64) (*repo.Category, error) {
+	tx, err := cr.db.Beginx()
 	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockCategoryTree(tx); err != nil {
+		return nil, err
+	}
+
+	if err := mergeCategories(tx, targetID, sourceIDs); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return cr.Get(targetID)
+}
+
+// mergeCategories moves the posts, trashed ones included, the subscribers
+// and the children of the sources to the target and deletes the sources.
+// The caller holds the tree lock.
+func mergeCategories(tx *sqlx.Tx, targetID int64, sourceIDs []int64) error {
+	if len(sourceIDs) == 0 {
+		return fmt.Errorf("%w: no categories to merge", repo.ErrInvalidArgument)
+	}
+	for _, id := range sourceIDs {
+		if id == targetID {
+			return fmt.Errorf("%w: a category can't be merged into itself", repo.ErrInvalidArgument)
+		}
+	}
+
+	if err := lockCategories(tx, append([]int64{targetID}, sourceIDs...)); err != nil {
 		return err
 	}
 
-	if rowsCount == 0 {
-		return sql.ErrNoRows
+	sources := pq.Array(sourceIDs)
+
+	// The children which aren't merged themselves move under the target.
+	children, err := queryIDs(tx, "SELECT id FROM categories WHERE parent_id=ANY($1) AND NOT id=ANY($1)", sources)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		if err := checkCategoryMove(tx, children, targetID, sourceIDs); err != nil {
+			return err
+		}
+		_, err = tx.Exec("UPDATE categories SET parent_id=$1 WHERE id=ANY($2)", targetID, pq.Array(children))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec("UPDATE posts SET category_id=$1 WHERE category_id=ANY($2)", targetID, sources)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO category_subscriptions(user_id, category_id)
+		SELECT DISTINCT user_id, $1::INTEGER FROM category_subscriptions
+		WHERE category_id=ANY($2)
+		ON CONFLICT DO NOTHING
+	`, targetID, sources)
+	if err != nil {
+		return err
+	}
+
+	// The old subscriptions go with their categories.
+	_, err = tx.Exec("DELETE FROM categories WHERE id=ANY($1)", sources)
+	return err
+}
+
+// lockCategoryTree serializes the writes which change the shape of the
+// tree or take a slug, so the depth and cycle checks stay true until
+// commit. Reads and posts referencing categories aren't blocked.
+func lockCategoryTree(tx *sqlx.Tx) error {
+	_, err := tx.Exec("LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE")
+	return err
+}
+
+// checkCategoryParent checks that the category id, or a new one when id
+// is 0, fits under parentID with its subtree.
+func checkCategoryParent(tx *sqlx.Tx, id, parentID int64) error {
+	var ids []int64
+	if id != 0 {
+		ids = []int64{id}
+	}
+	return checkCategoryMove(tx, ids, parentID, ids)
+}
+
+// checkCategoryMove checks that the subtrees of ids can be moved under
+// parentID without going deeper than MaxCategoryDepth, and that parentID
+// isn't inside any of the excluded categories, which would make a cycle.
+func checkCategoryMove(tx *sqlx.Tx, ids []int64, parentID int64, excluded []int64) error {
+	var (
+		levels int
+		inside bool
+	)
+
+	// levels counts the parent and its ancestors, so the moved categories
+	// end up at depth levels.
+	err := tx.QueryRow(`
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id FROM categories WHERE id=$1
+			UNION ALL
+			SELECT c.id, c.parent_id FROM categories c
+			INNER JOIN ancestors a ON c.id=a.parent_id
+		)
+		SELECT count(1), COALESCE(bool_or(id=ANY($2)), false) FROM ancestors
+	`, parentID, pq.Array(excluded)).Scan(&levels, &inside)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case levels == 0:
+		return fmt.Errorf("%w: parent category %d is not found", repo.ErrInvalidArgument, parentID)
+	case inside:
+		return fmt.Errorf("%w: a category can't be moved inside itself", repo.ErrInvalidArgument)
+	}
+
+	var height int
+	err = tx.QueryRow(`
+		WITH RECURSIVE subtree AS (
+			SELECT id, 0 AS level FROM categories WHERE id=ANY($1)
+			UNION ALL
+			SELECT c.id, s.level+1 FROM categories c
+			INNER JOIN subtree s ON c.parent_id=s.id
+		)
+		SELECT COALESCE(max(level), 0) FROM subtree
+	`, pq.Array(ids)).Scan(&height)
+	if err != nil {
+		return err
 	}
 
+	if levels+height > repo.MaxCategoryDepth {
+		return fmt.Errorf("%w: categories can't be nested deeper than %d levels", repo.ErrInvalidArgument, repo.MaxCategoryDepth)
+	}
+	return nil
+}
+
+// uniqueCategorySlug returns the slug of the title or its first variant
+// which isn't taken yet. The caller holds the tree lock.
+func uniqueCategorySlug(tx *sqlx.Tx, title string) (string, error) {
+	base := slug.Make(title)
+	if base == "" {
+		base = "category"
+	}
+
+	candidate := base
+	for n := 2; ; n++ {
+		var taken bool
+		err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM categories WHERE slug=$1)", candidate).Scan(&taken)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = slug.WithSuffix(base, n)
+	}
+}
+
+// categorySubtree selects the ids of a category and its descendants,
+// category is a placeholder.
+func categorySubtree(category string) string {
+	return `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id=` + category + `
+			UNION ALL
+			SELECT c.id FROM categories c
+			INNER JOIN subtree s ON c.parent_id=s.id
+		)
+		SELECT id FROM subtree`
+}
+
+func queryIDs(q sqlx.Queryer, query string, args ...interface{}) ([]int64, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// lockCategories locks the categories in the order of their ids, so
+// concurrent merges can't deadlock. It fails with sql.ErrNoRows if any
+// of them is missing.
+func lockCategories(tx *sqlx.Tx, ids []int64) error {
+	unique := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		unique[id] = true
+	}
+
+	rows, err := tx.Query("SELECT id FROM categories WHERE id=ANY($1) ORDER BY id FOR UPDATE", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	locked := 0
+	for rows.Next() {
+		locked++
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if locked != len(unique) {
+		return sql.ErrNoRows
+	}
 	return nil
 }
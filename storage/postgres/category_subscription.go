@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+)
+
+type categorySubscriptionRepo struct {
+	db *sqlx.DB
+}
+
+func NewCategorySubscription(db *sqlx.DB) repo.CategorySubscriptionStorageI {
+	return &categorySubscriptionRepo{
+		db: db,
+	}
+}
+
+func (sr *categorySubscriptionRepo) Subscribe(userID, categoryID int64) error {
+	// Selecting from categories tells a missing category from a repeated
+	// subscription.
+	query := `
+		WITH category AS (
+			SELECT id FROM categories WHERE id=$2
+		), subscribed AS (
+			INSERT INTO category_subscriptions(user_id, category_id)
+			SELECT $1, id FROM category
+			ON CONFLICT DO NOTHING
+		)
+		SELECT count(1) FROM category
+	`
+
+	var found int
+	if err := sr.db.QueryRow(query, userID, categoryID).Scan(&found); err != nil {
+		return err
+	}
+	if found == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (sr *categorySubscriptionRepo) Unsubscribe(userID, categoryID int64) error {
+	_, err := sr.db.Exec(
+		"DELETE FROM category_subscriptions WHERE user_id=$1 AND category_id=$2",
+		userID, categoryID,
+	)
+	return err
+}
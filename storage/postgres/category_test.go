@@ -1,6 +1,7 @@
 package postgres_test
 
 import (
+	"database/sql"
 	"testing"
 
 	"github.com/bxcodec/faker/v4"
@@ -42,6 +43,19 @@ func TestGetAllCategories(t *testing.T) {
 	require.GreaterOrEqual(t, int(result.Count), 1)
 }
 
+func TestGetAllCategoriesMaliciousSearch(t *testing.T) {
+	createCategory(t)
+
+	result, err := strg.Category().GetAll(&repo.GetAllCategoriesParams{
+		Limit:  10,
+		Page:   1,
+		Search: "%' OR 1=1 --",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, int32(0), result.Count)
+}
+
 func TestUpdateCategory(t *testing.T) {
 	c := createCategory(t)
 
@@ -56,6 +70,204 @@ func TestUpdateCategory(t *testing.T) {
 func TestDeleteCategory(t *testing.T) {
 	c := createCategory(t)
 
-	err := strg.Category().Delete(c.ID)
+	err := strg.Category().Delete(c.ID, 0)
+	require.NoError(t, err)
+}
+
+func TestDeleteCategoryInUse(t *testing.T) {
+	p := createPost(t)
+
+	err := strg.Category().Delete(p.CategoryID, 0)
+	require.ErrorIs(t, err, repo.ErrFailedPrecondition)
+
+	err = strg.Category().Delete(p.CategoryID, p.CategoryID)
+	require.ErrorIs(t, err, repo.ErrInvalidArgument)
+
+	err = strg.Category().Delete(p.CategoryID, -1)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	target := createCategory(t)
+	require.NoError(t, strg.Category().Delete(p.CategoryID, target.ID))
+
+	post, err := strg.Post().Get(p.ID)
+	require.NoError(t, err)
+	require.Equal(t, target.ID, post.CategoryID)
+
+	_, err = strg.Category().Get(p.CategoryID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestMergeCategories(t *testing.T) {
+	const subscriber = 701
+	target := createCategory(t)
+	first := createPost(t)
+	second := createPost(t)
+	require.NoError(t, strg.CategorySubscription().Subscribe(subscriber, first.CategoryID))
+	require.NoError(t, strg.CategorySubscription().Subscribe(subscriber, second.CategoryID))
+	require.NoError(t, strg.CategorySubscription().Subscribe(subscriber, target.ID))
+
+	_, err := strg.Category().Merge(target.ID, []int64{first.CategoryID, -1})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	merged, err := strg.Category().Merge(target.ID, []int64{first.CategoryID, second.CategoryID})
+	require.NoError(t, err)
+	require.Equal(t, target.ID, merged.ID)
+
+	for _, p := range []*repo.Post{first, second} {
+		post, err := strg.Post().Get(p.ID)
+		require.NoError(t, err)
+		require.Equal(t, target.ID, post.CategoryID)
+	}
+
+	var subscriptions int
+	err = db.QueryRow("SELECT count(1) FROM category_subscriptions WHERE user_id=$1", subscriber).Scan(&subscriptions)
+	require.NoError(t, err)
+	require.Equal(t, 1, subscriptions)
+}
+
+func createChildCategory(t *testing.T, parentID int64) *repo.Category {
+	category, err := strg.Category().Create(&repo.Category{
+		Title:    faker.Sentence(),
+		ParentID: parentID,
+	})
+	require.NoError(t, err)
+	return category
+}
+
+func TestCategorySlugs(t *testing.T) {
+	name := faker.UUIDDigit()
+
+	first, err := strg.Category().Create(&repo.Category{Title: "Slug " + name + "!"})
+	require.NoError(t, err)
+	require.Equal(t, "slug-"+name, first.Slug)
+
+	second, err := strg.Category().Create(&repo.Category{Title: "slug " + name + "?"})
+	require.NoError(t, err)
+	require.Equal(t, "slug-"+name+"-2", second.Slug)
+
+	_, err = strg.Category().Create(&repo.Category{Title: first.Title})
+	require.ErrorIs(t, err, repo.ErrAlreadyExists)
+
+	renamed, err := strg.Category().Update(&repo.Category{ID: first.ID, Title: faker.Sentence()})
+	require.NoError(t, err)
+	require.Equal(t, first.Slug, renamed.Slug)
+
+	found, err := strg.Category().GetBySlug(second.Slug)
+	require.NoError(t, err)
+	require.Equal(t, second.ID, found.ID)
+
+	_, err = strg.Category().GetBySlug("missing-" + name)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCategoryTree(t *testing.T) {
+	root := createCategory(t)
+	child := createChildCategory(t, root.ID)
+	grandchild := createChildCategory(t, child.ID)
+
+	_, err := strg.Category().Create(&repo.Category{Title: faker.Sentence(), ParentID: grandchild.ID})
+	require.ErrorIs(t, err, repo.ErrInvalidArgument)
+
+	_, err = strg.Category().Create(&repo.Category{Title: faker.Sentence(), ParentID: -1})
+	require.ErrorIs(t, err, repo.ErrInvalidArgument)
+
+	_, err = strg.Category().Move(root.ID, grandchild.ID)
+	require.ErrorIs(t, err, repo.ErrInvalidArgument)
+
+	other := createCategory(t)
+	_, err = strg.Category().Move(child.ID, createChildCategory(t, other.ID).ID)
+	require.ErrorIs(t, err, repo.ErrInvalidArgument)
+
+	tree, err := strg.Category().GetTree(root.ID)
+	require.NoError(t, err)
+	require.Len(t, tree, 3)
+	require.Equal(t, root.ID, tree[0].ID)
+	require.Equal(t, child.ID, tree[1].ID)
+	require.Equal(t, grandchild.ID, tree[2].ID)
+
+	moved, err := strg.Category().Move(grandchild.ID, 0)
+	require.NoError(t, err)
+	require.Zero(t, moved.ParentID)
+
+	_, err = strg.Category().GetTree(-1)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestGetAllPostsIncludeSubcategories(t *testing.T) {
+	root := createCategory(t)
+	child := createChildCategory(t, root.ID)
+	inRoot := createPublishedPost(t, 1, root.ID)
+	inChild := createPublishedPost(t, 1, child.ID)
+
+	params := &repo.GetAllPostsParams{Limit: 10, CategoryID: root.ID}
+	result, err := strg.Post().GetAll(params)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), result.Count)
+	require.True(t, containsPost(result.Posts, inRoot.ID))
+
+	params.IncludeSubcategories = true
+	result, err = strg.Post().GetAll(params)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), result.Count)
+	require.True(t, containsPost(result.Posts, inChild.ID))
+}
+
+func TestDeleteCategoryWithChildren(t *testing.T) {
+	root := createCategory(t)
+	child := createChildCategory(t, root.ID)
+
+	err := strg.Category().Delete(root.ID, 0)
+	require.ErrorIs(t, err, repo.ErrFailedPrecondition)
+
+	err = strg.Category().Delete(root.ID, child.ID)
+	require.ErrorIs(t, err, repo.ErrInvalidArgument)
+
+	target := createCategory(t)
+	require.NoError(t, strg.Category().Delete(root.ID, target.ID))
+
+	moved, err := strg.Category().Get(child.ID)
+	require.NoError(t, err)
+	require.Equal(t, target.ID, moved.ParentID)
+}
+
+func TestCategoryStats(t *testing.T) {
+	c := createCategory(t)
+	createPublishedPost(t, 1, c.ID)
+	createPublishedPost(t, 2, c.ID)
+	createPost(t)
+	for _, userID := range []int64{801, 802, 803} {
+		require.NoError(t, strg.CategorySubscription().Subscribe(userID, c.ID))
+	}
+
+	require.NoError(t, strg.Category().RefreshStats())
+
+	result, err := strg.Category().GetAll(&repo.GetAllCategoriesParams{
+		Limit:  10,
+		Page:   1,
+		Search: c.Title,
+		Sort:   repo.CategorySortMostPosts,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Categories, 1)
+
+	stats := result.Categories[0].Stats
+	require.NotNil(t, stats)
+	require.Equal(t, int64(2), stats.PostsCount)
+	require.Equal(t, int64(2), stats.WeeklyPosts)
+	require.Equal(t, int64(3), stats.FollowersCount)
+	require.Equal(t, int64(3), stats.WeeklyFollowers)
+	require.False(t, stats.LastPostAt.IsZero())
+
+	result, err = strg.Category().GetAll(&repo.GetAllCategoriesParams{
+		Limit: 10,
+		Page:  1,
+		Sort:  repo.CategorySortPopular,
+	})
 	require.NoError(t, err)
+	for i := 1; i < len(result.Categories); i++ {
+		require.GreaterOrEqual(t, result.Categories[i-1].Stats.FollowersCount, result.Categories[i].Stats.FollowersCount)
+	}
+
+	_, err = strg.Category().GetAll(&repo.GetAllCategoriesParams{Sort: "unknown"})
+	require.ErrorIs(t, err, repo.ErrInvalidArgument)
 }
@@ -2,9 +2,12 @@ package postgres
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/mirasildev/medium_post_service/pkg/utils"
+	"github.com/mirasildev/medium_post_service/storage/postgres/internal/qb"
 	"github.com/mirasildev/medium_post_service/storage/repo"
 
 	"github.com/jmoiron/sqlx"
@@ -20,24 +23,86 @@ func NewComment(db *sqlx.DB) repo.CommentStorageI {
 	}
 }
 
+const commentColumns = `
+	id,
+	user_id,
+	post_id,
+	parent_id,
+	depth,
+	replies_count,
+	description,
+	created_at,
+	updated_at,
+	deleted_at`
+
+func scanComment(row scanner) (*repo.Comment, error) {
+	var (
+		res       repo.Comment
+		parentID  sql.NullInt64
+		updatedAt sql.NullTime
+		deletedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&res.ID,
+		&res.UserID,
+		&res.PostID,
+		&parentID,
+		&res.Depth,
+		&res.RepliesCount,
+		&res.Description,
+		&res.CreatedAt,
+		&updatedAt,
+		&deletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res.ParentID = parentID.Int64
+	res.UpdatedAt = updatedAt.Time
+	res.DeletedAt = deletedAt.Time
+	return &res, nil
+}
+
 func (cr *commentRepo) Create(comment *repo.Comment) (*repo.Comment, error) {
+	tx, err := cr.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	comment.Depth = 0
+	if comment.ParentID != 0 {
+		if err := addReply(tx, comment); err != nil {
+			return nil, err
+		}
+	}
+
+	// Selecting from posts turns a missing or trashed post into no row.
 	query := `
 		INSERT INTO comments (
 			user_id,
 		    post_id,
+		    parent_id,
+		    depth,
 		    description
-		) VALUES($1, $2, $3)
+		)
+		SELECT $1, id, $3, $4, $5 FROM posts
+		WHERE id=$2 AND deleted_at IS NULL
 		RETURNING id, description, created_at
 	`
 
-	row := cr.db.QueryRow(
+	row := tx.QueryRow(
 		query,
 		comment.UserID,
 		comment.PostID,
+		utils.NullInt64(comment.ParentID),
+		comment.Depth,
 		comment.Description,
 	)
 
-	err := row.Scan(
+	err = row.Scan(
 		&comment.ID,
 		&comment.Description,
 		&comment.CreatedAt,
@@ -46,39 +111,65 @@ func (cr *commentRepo) Create(comment *repo.Comment) (*repo.Comment, error) {
 		return nil, err
 	}
 
+	if err := addComments(tx, comment.PostID, 1); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	return comment, nil
 }
 
-func (cr *commentRepo) Get(id int64) (*repo.Comment, error) {
+// addReply checks that comment can reply to its parent, sets its depth
+// and counts it in the parent. The parent row stays locked until the
+// transaction ends so it can't be deleted meanwhile.
+func addReply(tx *sqlx.Tx, comment *repo.Comment) error {
 	var (
-		res repo.Comment
-		updatedAt sql.NullTime
+		postID    int64
+		depth     int32
+		deletedAt sql.NullTime
 	)
 
+	err := tx.QueryRow(
+		"SELECT post_id, depth, deleted_at FROM comments WHERE id=$1 FOR UPDATE",
+		comment.ParentID,
+	).Scan(&postID, &depth, &deletedAt)
+	if err != nil {
+		return err
+	}
+
+	if comment.PostID == 0 {
+		comment.PostID = postID
+	}
+
+	switch {
+	case postID != comment.PostID:
+		return fmt.Errorf("%w: the parent comment belongs to another post", repo.ErrInvalidArgument)
+	case deletedAt.Valid:
+		return fmt.Errorf("%w: the parent comment is deleted", repo.ErrInvalidArgument)
+	case depth >= repo.MaxCommentDepth:
+		return fmt.Errorf("%w: replies can't be nested deeper than %d levels", repo.ErrInvalidArgument, repo.MaxCommentDepth)
+	}
+	comment.Depth = depth + 1
+
+	_, err = tx.Exec("UPDATE comments SET replies_count=replies_count+1 WHERE id=$1", comment.ParentID)
+	return err
+}
+
+func (cr *commentRepo) Get(id int64) (*repo.Comment, error) {
 	query := `
-		SELECT 
-			id,
-			user_id,
-			post_id,
-			description,
-			created_at,
-			updated_at
+		SELECT ` + commentColumns + `
 		FROM comments WHERE id=$1
 	`
 
-	err := cr.db.QueryRow(query, id).Scan(
-		&res.ID,
-		&res.UserID,
-		&res.PostID,
-		&res.Description,
-		&res.CreatedAt,
-		&updatedAt,
-	)
-	if err != nil {
-		return nil, err
-	}
-	res.UpdatedAt = updatedAt.Time
-	return &res, nil
+	return scanComment(cr.db.QueryRow(query, id))
+}
+
+var commentSortColumns = map[string]string{
+	"created_at": "created_at",
+	"id":         "id",
 }
 
 func (cr *commentRepo) GetAll(params *repo.GetAllCommentsParams) (*repo.GetAllCommentsResult, error) {
@@ -86,32 +177,41 @@ func (cr *commentRepo) GetAll(params *repo.GetAllCommentsParams) (*repo.GetAllCo
 		Comments: make([]*repo.Comment, 0),
 	}
 
-	offset := (params.Page - 1) * params.Limit
-
-	limit := fmt.Sprintf(" LIMIT %d OFFSET %d ", params.Limit, offset)
-
-	filter := " WHERE true "
+	b := qb.New()
+	// The comments of trashed posts are hidden along with them.
+	b.Where("post_id IN (SELECT id FROM posts WHERE deleted_at IS NULL)")
 	if params.UserID != 0 {
-		filter += fmt.Sprintf(" AND c.user_id=%d ", params.UserID)
+		b.Where("user_id=?", params.UserID)
 	}
 
 	if params.PostID != 0 {
-		filter += fmt.Sprintf(" AND c.post_id=%d ", params.PostID)
+		b.Where("post_id=?", params.PostID)
 	}
 
-	query := `
-		SELECT
-			id,
-			user_id,
-			post_id,
-			description,
-			created_at,
-			updated_at
-		FROM comments 
-		` + filter + `
-		ORDER BY created_at desc` + limit
-
-	rows, err := cr.db.Query(query)
+	direction := "desc"
+	if params.ParentID != 0 {
+		b.Where("parent_id=?", params.ParentID)
+		direction = "asc"
+	} else if params.TopLevel {
+		b.Where("parent_id IS NULL")
+	}
+
+	for _, column := range []string{"created_at", "id"} {
+		if err := b.OrderBy(commentSortColumns, column, direction); err != nil {
+			return nil, err
+		}
+	}
+
+	// The count ignores the keyset so it is built before it is applied.
+	queryCount, countArgs := b.BuildCount("SELECT count(1) FROM comments")
+
+	applyKeyset(b, "created_at, id", direction, keysetArgs(params.After), params.Limit, params.Page)
+
+	query, args := b.Build(`
+		SELECT ` + commentColumns + `
+		FROM comments`)
+
+	rows, err := cr.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -119,29 +219,23 @@ func (cr *commentRepo) GetAll(params *repo.GetAllCommentsParams) (*repo.GetAllCo
 	defer rows.Close()
 
 	for rows.Next() {
-		var (
-			c         repo.Comment
-			updatedAt sql.NullTime
-		)
-
-		err := rows.Scan(
-			&c.ID,
-			&c.UserID,
-			&c.PostID,
-			&c.Description,
-			&c.CreatedAt,
-			&updatedAt,
-		)
+		c, err := scanComment(rows)
 		if err != nil {
 			return nil, err
 		}
-		c.UpdatedAt = updatedAt.Time
-		result.Comments = append(result.Comments, &c)
+		result.Comments = append(result.Comments, c)
 	}
 
-	queryCount := "SELECT count(1) FROM comments " + filter
+	if params.Limit > 0 && len(result.Comments) > int(params.Limit) {
+		result.Comments = result.Comments[:params.Limit]
+		last := result.Comments[len(result.Comments)-1]
+		result.Next = &repo.Keyset{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		}
+	}
 
-	err = cr.db.QueryRow(queryCount).Scan(&result.Count)
+	err = cr.db.QueryRow(queryCount, countArgs...).Scan(&result.Count)
 	if err != nil {
 		return nil, err
 	}
@@ -151,43 +245,111 @@ func (cr *commentRepo) GetAll(params *repo.GetAllCommentsParams) (*repo.GetAllCo
 
 func (cr *commentRepo) Update(com *repo.Comment) (*repo.Comment, error) {
 	query := `
-		UPDATE comments SET description=$1, updated_at=$2 WHERE id=$3
-		RETURNING id, description, updated_at
-	`
-
-	var (
-		res repo.Comment
-		updatedAt sql.NullTime
-	)
+		UPDATE comments SET description=$1, updated_at=$2
+		WHERE id=$3 AND user_id=$4 AND deleted_at IS NULL
+		RETURNING ` + commentColumns
 
-	err := cr.db.QueryRow(query, com.Description, time.Now(), com.ID).Scan(
-		&res.ID,
-		&res.Description,
-		&updatedAt,
-	)
+	res, err := scanComment(cr.db.QueryRow(query, com.Description, time.Now(), com.ID, com.UserID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, cr.notChangedErr(com.ID)
+	}
 	if err != nil {
 		return nil, err
 	}
-	
-	res.UpdatedAt = updatedAt.Time
-	return &res, nil
+
+	return res, nil
 }
 
-func (cr *commentRepo) Delete(id int64) error {
-	query := "DELETE FROM comments WHERE id=$1"
-	res, err := cr.db.Exec(query, id)
+func (cr *commentRepo) Delete(id, userID int64) error {
+	tx, err := cr.db.Beginx()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	rowsCount, err := res.RowsAffected()
+	var (
+		postID       int64
+		parentID     sql.NullInt64
+		repliesCount int32
+	)
+
+	query := `
+		SELECT c.post_id, c.parent_id, c.replies_count FROM comments c
+		WHERE c.id=$1 AND c.deleted_at IS NULL AND (
+			c.user_id=$2 OR
+			EXISTS (SELECT 1 FROM posts p WHERE p.id=c.post_id AND p.user_id=$2)
+		)
+		FOR UPDATE
+	`
+	err = tx.QueryRow(query, id, userID).Scan(&postID, &parentID, &repliesCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return cr.notChangedErr(id)
+	}
 	if err != nil {
 		return err
 	}
 
-	if rowsCount == 0 {
-		return sql.ErrNoRows
+	// Tombstones aren't counted either.
+	if err := addComments(tx, postID, -1); err != nil {
+		return err
+	}
+
+	// Replies keep their place in the thread under a tombstone.
+	if repliesCount > 0 {
+		_, err = tx.Exec("UPDATE comments SET description='', deleted_at=CURRENT_TIMESTAMP WHERE id=$1", id)
+		if err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	if _, err := tx.Exec("DELETE FROM comments WHERE id=$1", id); err != nil {
+		return err
 	}
 
-	return nil
+	// A tombstone goes away with its last reply, and so may its parent.
+	query = `
+		UPDATE comments SET replies_count=replies_count-1 WHERE id=$1
+		RETURNING parent_id, replies_count, deleted_at IS NOT NULL
+	`
+	for parentID.Valid {
+		var tombstone bool
+		id := parentID.Int64
+		err = tx.QueryRow(query, id).Scan(&parentID, &repliesCount, &tombstone)
+		if err != nil {
+			return err
+		}
+		if repliesCount > 0 || !tombstone {
+			break
+		}
+
+		if _, err := tx.Exec("DELETE FROM comments WHERE id=$1", id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// addComments moves the comments counter of the post by delta, the post
+// row is locked after the comments like addReactions does.
+func addComments(tx *sqlx.Tx, postID int64, delta int) error {
+	_, err := tx.Exec("UPDATE posts SET comments_count=comments_count+$1 WHERE id=$2", delta, postID)
+	return err
+}
+
+// notChangedErr tells apart a missing comment from someone else's one
+// after a write filtered by the owner matched no rows. Tombstones count
+// as missing.
+func (cr *commentRepo) notChangedErr(id int64) error {
+	var exists bool
+	err := cr.db.QueryRow("SELECT EXISTS (SELECT 1 FROM comments WHERE id=$1 AND deleted_at IS NULL)", id).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return repo.ErrPermissionDenied
+	}
+	return sql.ErrNoRows
 }
@@ -0,0 +1,194 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/bxcodec/faker/v4"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func createComment(t *testing.T, postID, userID int64) *repo.Comment {
+	c, err := strg.Comment().Create(&repo.Comment{
+		PostID:      postID,
+		UserID:      userID,
+		Description: faker.Sentence(),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, c)
+
+	return c
+}
+
+func TestUpdateComment(t *testing.T) {
+	p := createPost(t)
+	c := createComment(t, p.ID, p.UserID+1)
+
+	c.Description = faker.Sentence()
+	updated, err := strg.Comment().Update(c)
+	require.NoError(t, err)
+	require.Equal(t, c.Description, updated.Description)
+	require.Equal(t, c.UserID, updated.UserID)
+
+	_, err = strg.Comment().Update(&repo.Comment{
+		ID:          c.ID,
+		UserID:      c.UserID + 1,
+		Description: faker.Sentence(),
+	})
+	require.ErrorIs(t, err, repo.ErrPermissionDenied)
+
+	_, err = strg.Comment().Update(&repo.Comment{
+		ID:          -1,
+		UserID:      c.UserID,
+		Description: faker.Sentence(),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestDeleteComment(t *testing.T) {
+	p := createPost(t)
+	c := createComment(t, p.ID, p.UserID+1)
+
+	err := strg.Comment().Delete(c.ID, c.UserID+1)
+	require.ErrorIs(t, err, repo.ErrPermissionDenied)
+
+	err = strg.Comment().Delete(c.ID, c.UserID)
+	require.NoError(t, err)
+
+	err = strg.Comment().Delete(c.ID, c.UserID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestPostAuthorDeletesComment(t *testing.T) {
+	p := createPost(t)
+	c := createComment(t, p.ID, p.UserID+1)
+
+	err := strg.Comment().Delete(c.ID, p.UserID)
+	require.NoError(t, err)
+}
+
+func createReply(t *testing.T, parent *repo.Comment, userID int64) *repo.Comment {
+	c, err := strg.Comment().Create(&repo.Comment{
+		PostID:      parent.PostID,
+		ParentID:    parent.ID,
+		UserID:      userID,
+		Description: faker.Sentence(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, parent.Depth+1, c.Depth)
+
+	return c
+}
+
+func TestCommentReplies(t *testing.T) {
+	p := createPost(t)
+	root := createComment(t, p.ID, p.UserID)
+	first := createReply(t, root, p.UserID+1)
+	second := createReply(t, root, p.UserID+2)
+
+	root, err := strg.Comment().Get(root.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), root.RepliesCount)
+
+	replies, err := strg.Comment().GetAll(&repo.GetAllCommentsParams{
+		Limit:    1,
+		Page:     1,
+		ParentID: root.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), replies.Count)
+	require.Equal(t, first.ID, replies.Comments[0].ID)
+
+	replies, err = strg.Comment().GetAll(&repo.GetAllCommentsParams{
+		Limit:    1,
+		ParentID: root.ID,
+		After:    replies.Next,
+	})
+	require.NoError(t, err)
+	require.Equal(t, second.ID, replies.Comments[0].ID)
+	require.Nil(t, replies.Next)
+
+	roots, err := strg.Comment().GetAll(&repo.GetAllCommentsParams{
+		Limit:    10,
+		Page:     1,
+		PostID:   p.ID,
+		TopLevel: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, roots.Comments, 1)
+	require.Equal(t, root.ID, roots.Comments[0].ID)
+}
+
+func TestCommentMaxDepth(t *testing.T) {
+	p := createPost(t)
+	c := createComment(t, p.ID, p.UserID)
+	for i := 0; i < repo.MaxCommentDepth; i++ {
+		c = createReply(t, c, p.UserID)
+	}
+
+	_, err := strg.Comment().Create(&repo.Comment{
+		PostID:      p.ID,
+		ParentID:    c.ID,
+		UserID:      p.UserID,
+		Description: faker.Sentence(),
+	})
+	require.ErrorIs(t, err, repo.ErrInvalidArgument)
+}
+
+func TestDeleteCommentWithReplies(t *testing.T) {
+	p := createPost(t)
+	root := createComment(t, p.ID, p.UserID+1)
+	reply := createReply(t, root, p.UserID+2)
+
+	err := strg.Comment().Delete(root.ID, root.UserID)
+	require.NoError(t, err)
+
+	tombstone, err := strg.Comment().Get(root.ID)
+	require.NoError(t, err)
+	require.False(t, tombstone.DeletedAt.IsZero())
+	require.Empty(t, tombstone.Description)
+
+	_, err = strg.Comment().Create(&repo.Comment{
+		PostID:      p.ID,
+		ParentID:    root.ID,
+		UserID:      p.UserID,
+		Description: faker.Sentence(),
+	})
+	require.ErrorIs(t, err, repo.ErrInvalidArgument)
+
+	err = strg.Comment().Delete(reply.ID, reply.UserID)
+	require.NoError(t, err)
+
+	_, err = strg.Comment().Get(reply.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// The tombstone goes with its last reply.
+	_, err = strg.Comment().Get(root.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestDeleteLastReplyRemovesTombstoneChain(t *testing.T) {
+	p := createPost(t)
+	root := createComment(t, p.ID, p.UserID)
+	middle := createReply(t, root, p.UserID)
+	sibling := createReply(t, root, p.UserID)
+	leaf := createReply(t, middle, p.UserID)
+
+	require.NoError(t, strg.Comment().Delete(root.ID, p.UserID))
+	require.NoError(t, strg.Comment().Delete(middle.ID, p.UserID))
+	require.NoError(t, strg.Comment().Delete(leaf.ID, p.UserID))
+
+	_, err := strg.Comment().Get(middle.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// The root still has a reply, so it stays as a tombstone.
+	tombstone, err := strg.Comment().Get(root.ID)
+	require.NoError(t, err)
+	require.False(t, tombstone.DeletedAt.IsZero())
+	require.Equal(t, int32(1), tombstone.RepliesCount)
+
+	require.NoError(t, strg.Comment().Delete(sibling.ID, p.UserID))
+	_, err = strg.Comment().Get(root.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mirasildev/medium_post_service/storage/postgres/internal/qb"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+)
+
+type feedRepo struct {
+	db *sqlx.DB
+}
+
+func NewFeed(db *sqlx.DB) repo.FeedStorageI {
+	return &feedRepo{
+		db: db,
+	}
+}
+
+func (fr *feedRepo) GetFeed(params *repo.GetFeedParams) (*repo.GetAllPostsResult, error) {
+	result := repo.GetAllPostsResult{
+		Posts: make([]*repo.Post, 0),
+	}
+
+	b := qb.New()
+	user := b.Arg(params.UserID)
+	b.Where("status=?", repo.PostStatusPublished)
+	b.Where("deleted_at IS NULL")
+	b.Where("user_id<>" + user)
+	b.Where(`(
+		user_id IN (SELECT followee_id FROM follows WHERE follower_id=` + user + `) OR
+		category_id IN (SELECT category_id FROM category_subscriptions WHERE user_id=` + user + `)
+	)`)
+	if !params.IncludeSeen {
+		b.Where(`NOT EXISTS (
+			SELECT 1 FROM feed_seen_posts s
+			WHERE s.user_id=` + user + ` AND s.post_id=posts.id
+		)`)
+	}
+	for _, column := range []string{"created_at", "id"} {
+		if err := b.OrderBy(postSortColumns, column, "desc"); err != nil {
+			return nil, err
+		}
+	}
+
+	// The count ignores the keyset so it is built before it is applied.
+	queryCount, countArgs := b.BuildCount("SELECT count(1) FROM posts")
+
+	applyKeyset(b, "created_at, id", "desc", keysetArgs(params.After), params.Limit, 1)
+
+	query, args := b.Build(`
+		SELECT
+			` + postColumns + `,
+			` + viewerReactionColumn(user) + `
+		FROM posts`)
+
+	rows, err := fr.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := scanPostWithReaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		result.Posts = append(result.Posts, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if params.Limit > 0 && len(result.Posts) > int(params.Limit) {
+		result.Posts = result.Posts[:params.Limit]
+		last := result.Posts[len(result.Posts)-1]
+		result.Next = &repo.Keyset{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		}
+	}
+
+	err = fr.db.QueryRow(queryCount, countArgs...).Scan(&result.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (fr *feedRepo) MarkSeen(userID int64, postIDs []int64) error {
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO feed_seen_posts(user_id, post_id)
+		SELECT $1, id FROM posts WHERE id=ANY($2::INTEGER[])
+		ON CONFLICT DO NOTHING
+	`
+
+	_, err := fr.db.Exec(query, userID, pq.Array(postIDs))
+	return err
+}
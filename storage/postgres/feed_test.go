@@ -0,0 +1,84 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/bxcodec/faker/v4"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func createPublishedPost(t *testing.T, userID, categoryID int64) *repo.Post {
+	p, err := strg.Post().Create(&repo.Post{
+		Title:       faker.Sentence(),
+		Description: faker.Paragraph(),
+		UserID:      userID,
+		CategoryID:  categoryID,
+		Status:      repo.PostStatusPublished,
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func TestGetFeed(t *testing.T) {
+	const (
+		reader   = 401
+		followed = 402
+		stranger = 403
+	)
+	subscribed := createCategory(t)
+	other := createCategory(t)
+
+	require.NoError(t, strg.Follow().Follow(reader, followed))
+	require.NoError(t, strg.Follow().Follow(reader, followed))
+	require.NoError(t, strg.CategorySubscription().Subscribe(reader, subscribed.ID))
+
+	byFollowed := createPublishedPost(t, followed, other.ID)
+	inCategory := createPublishedPost(t, stranger, subscribed.ID)
+	own := createPublishedPost(t, reader, subscribed.ID)
+	unrelated := createPublishedPost(t, stranger, other.ID)
+
+	params := &repo.GetFeedParams{
+		UserID: reader,
+		Limit:  1,
+	}
+	first, err := strg.Feed().GetFeed(params)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), first.Count)
+	require.Len(t, first.Posts, 1)
+	require.Equal(t, inCategory.ID, first.Posts[0].ID)
+	require.NotNil(t, first.Next)
+
+	params.After = first.Next
+	second, err := strg.Feed().GetFeed(params)
+	require.NoError(t, err)
+	require.Len(t, second.Posts, 1)
+	require.Equal(t, byFollowed.ID, second.Posts[0].ID)
+	require.Nil(t, second.Next)
+
+	require.NoError(t, strg.Feed().MarkSeen(reader, []int64{inCategory.ID, -1}))
+
+	unseen, err := strg.Feed().GetFeed(&repo.GetFeedParams{UserID: reader, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, unseen.Posts, 1)
+	require.Equal(t, byFollowed.ID, unseen.Posts[0].ID)
+	require.False(t, containsPost(unseen.Posts, own.ID))
+	require.False(t, containsPost(unseen.Posts, unrelated.ID))
+
+	all, err := strg.Feed().GetFeed(&repo.GetFeedParams{UserID: reader, Limit: 10, IncludeSeen: true})
+	require.NoError(t, err)
+	require.Len(t, all.Posts, 2)
+
+	require.NoError(t, strg.Follow().Unfollow(reader, followed))
+	require.NoError(t, strg.CategorySubscription().Unsubscribe(reader, subscribed.ID))
+
+	empty, err := strg.Feed().GetFeed(&repo.GetFeedParams{UserID: reader, Limit: 10, IncludeSeen: true})
+	require.NoError(t, err)
+	require.Empty(t, empty.Posts)
+}
+
+func TestSubscribeMissingCategory(t *testing.T) {
+	err := strg.CategorySubscription().Subscribe(401, -1)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
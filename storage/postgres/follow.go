@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+)
+
+type followRepo struct {
+	db *sqlx.DB
+}
+
+func NewFollow(db *sqlx.DB) repo.FollowStorageI {
+	return &followRepo{
+		db: db,
+	}
+}
+
+func (fr *followRepo) Follow(followerID, followeeID int64) error {
+	query := `
+		INSERT INTO follows(follower_id, followee_id) VALUES($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+
+	_, err := fr.db.Exec(query, followerID, followeeID)
+	return err
+}
+
+func (fr *followRepo) Unfollow(followerID, followeeID int64) error {
+	_, err := fr.db.Exec(
+		"DELETE FROM follows WHERE follower_id=$1 AND followee_id=$2",
+		followerID, followeeID,
+	)
+	return err
+}
@@ -0,0 +1,127 @@
+// Package qb builds the dynamic part of list queries. Every value ends up
+// in a positional placeholder and ordering is limited to allow-listed
+// columns, so request parameters never become part of the SQL text.
+package qb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidSort = errors.New("invalid sort")
+
+type Builder struct {
+	conds     []string
+	args      []interface{}
+	orders    []string
+	limit     int32
+	offset    int32
+	limited   bool
+	offsetSet bool
+}
+
+func New() *Builder {
+	return &Builder{}
+}
+
+// Arg binds v to the next placeholder and returns it, e.g. "$3".
+func (b *Builder) Arg(v interface{}) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// Where adds a condition joined with AND. Every '?' in cond is replaced
+// with a placeholder bound to the matching element of args.
+func (b *Builder) Where(cond string, args ...interface{}) *Builder {
+	var sb strings.Builder
+	i := 0
+	for _, r := range cond {
+		if r == '?' && i < len(args) {
+			sb.WriteString(b.Arg(args[i]))
+			i++
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	b.conds = append(b.conds, sb.String())
+	return b
+}
+
+// OrderBy appends a sort key. column is the public name of a sort key and
+// is resolved through allowed to its SQL expression, direction must be
+// "asc" or "desc" in any case and defaults to "desc".
+func (b *Builder) OrderBy(allowed map[string]string, column, direction string) error {
+	expr, ok := allowed[column]
+	if !ok {
+		return fmt.Errorf("%w: unknown column %q", ErrInvalidSort, column)
+	}
+
+	switch strings.ToLower(direction) {
+	case "", "desc":
+		direction = "DESC"
+	case "asc":
+		direction = "ASC"
+	default:
+		return fmt.Errorf("%w: unknown direction %q", ErrInvalidSort, direction)
+	}
+
+	b.orders = append(b.orders, expr+" "+direction)
+	return nil
+}
+
+// Page limits the query to the page'th page of limit rows, pages start from 1.
+func (b *Builder) Page(limit, page int32) *Builder {
+	if page < 1 {
+		page = 1
+	}
+	b.limit = limit
+	b.offset = (page - 1) * limit
+	b.limited = true
+	b.offsetSet = true
+	return b
+}
+
+// Limit sets the number of rows without touching the offset. Keyset
+// pages use it alone, offset pages may call it after Page to read one
+// more row than the page size and learn whether a next page exists.
+func (b *Builder) Limit(limit int32) *Builder {
+	b.limit = limit
+	b.limited = true
+	return b
+}
+
+// Build appends the conditions, ordering and paging to base.
+func (b *Builder) Build(base string) (string, []interface{}) {
+	args := append([]interface{}{}, b.args...)
+	query := base + b.where()
+
+	if len(b.orders) > 0 {
+		query += " ORDER BY " + strings.Join(b.orders, ", ")
+	}
+
+	if b.limited {
+		args = append(args, b.limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	if b.offsetSet {
+		args = append(args, b.offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	return query, args
+}
+
+// BuildCount appends only the conditions to base, it is meant for the
+// count(1) query of the same list.
+func (b *Builder) BuildCount(base string) (string, []interface{}) {
+	return base + b.where(), append([]interface{}{}, b.args...)
+}
+
+func (b *Builder) where() string {
+	if len(b.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(b.conds, " AND ")
+}
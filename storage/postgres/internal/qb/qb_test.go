@@ -0,0 +1,102 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/mirasildev/medium_post_service/storage/postgres/internal/qb"
+	"github.com/stretchr/testify/require"
+)
+
+var sortColumns = map[string]string{
+	"created_at": "created_at",
+	"id":         "id",
+}
+
+func TestBuild(t *testing.T) {
+	b := qb.New()
+	b.Where("status=?", "published")
+	b.Where("category_id=? AND user_id=?", int64(3), int64(5))
+	require.NoError(t, b.OrderBy(sortColumns, "created_at", "asc"))
+	require.NoError(t, b.OrderBy(sortColumns, "id", ""))
+	b.Page(10, 3)
+
+	query, args := b.Build("SELECT id FROM posts")
+	require.Equal(t, "SELECT id FROM posts WHERE status=$1 AND category_id=$2 AND user_id=$3 ORDER BY created_at ASC, id DESC LIMIT $4 OFFSET $5", query)
+	require.Equal(t, []interface{}{"published", int64(3), int64(5), int32(10), int32(20)}, args)
+
+	query, args = b.BuildCount("SELECT count(1) FROM posts")
+	require.Equal(t, "SELECT count(1) FROM posts WHERE status=$1 AND category_id=$2 AND user_id=$3", query)
+	require.Equal(t, []interface{}{"published", int64(3), int64(5)}, args)
+}
+
+func TestBuildWithoutConditions(t *testing.T) {
+	query, args := qb.New().Page(10, 0).Build("SELECT id FROM categories")
+	require.Equal(t, "SELECT id FROM categories LIMIT $1 OFFSET $2", query)
+	require.Equal(t, []interface{}{int32(10), int32(0)}, args)
+}
+
+func TestLimit(t *testing.T) {
+	query, args := qb.New().Limit(11).Build("SELECT id FROM posts")
+	require.Equal(t, "SELECT id FROM posts LIMIT $1", query)
+	require.Equal(t, []interface{}{int32(11)}, args)
+
+	query, args = qb.New().Page(10, 2).Limit(11).Build("SELECT id FROM posts")
+	require.Equal(t, "SELECT id FROM posts LIMIT $1 OFFSET $2", query)
+	require.Equal(t, []interface{}{int32(11), int32(10)}, args)
+}
+
+func TestArg(t *testing.T) {
+	b := qb.New()
+	q := b.Arg("go")
+	b.Where("search_vector @@ websearch_to_tsquery('simple', "+q+") AND category_id=?", int64(1))
+
+	query, args := b.BuildCount("SELECT count(1) FROM posts")
+	require.Equal(t, "SELECT count(1) FROM posts WHERE search_vector @@ websearch_to_tsquery('simple', $1) AND category_id=$2", query)
+	require.Equal(t, []interface{}{"go", int64(1)}, args)
+}
+
+func TestMaliciousValuesStayInArgs(t *testing.T) {
+	values := []string{
+		"'; DROP TABLE posts; --",
+		"%' OR '1'='1",
+		"?",
+		"$1",
+		`\'; SELECT pg_sleep(10); --`,
+	}
+
+	for _, v := range values {
+		t.Run(v, func(t *testing.T) {
+			b := qb.New()
+			b.Where("title ilike '%' || ? || '%'", v)
+
+			query, args := b.Build("SELECT id FROM posts")
+			require.Equal(t, "SELECT id FROM posts WHERE title ilike '%' || $1 || '%'", query)
+			require.Equal(t, []interface{}{v}, args)
+		})
+	}
+}
+
+func TestOrderByRejectsUnknownValues(t *testing.T) {
+	tests := []struct {
+		name      string
+		column    string
+		direction string
+	}{
+		{name: "injected direction", column: "created_at", direction: "desc; DROP TABLE posts"},
+		{name: "subquery direction", column: "created_at", direction: "asc, (SELECT 1)"},
+		{name: "unknown column", column: "password", direction: "asc"},
+		{name: "injected column", column: "created_at; --", direction: "asc"},
+		{name: "sql expression as column", column: "1", direction: "desc"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := qb.New()
+			err := b.OrderBy(sortColumns, tc.column, tc.direction)
+			require.ErrorIs(t, err, qb.ErrInvalidSort)
+
+			query, _ := b.Build("SELECT id FROM posts")
+			require.Equal(t, "SELECT id FROM posts", query)
+		})
+	}
+}
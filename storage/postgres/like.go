@@ -3,8 +3,10 @@ package postgres
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/mirasildev/medium_post_service/storage/postgres/internal/qb"
 	"github.com/mirasildev/medium_post_service/storage/repo"
 )
 
@@ -19,36 +21,153 @@ func NewLike(db *sqlx.DB) repo.LikeStorageI {
 }
 
 func (lr *likeRepo) CreateOrUpdate(l *repo.Like) (*repo.Like, error) {
-	like, err := lr.Get(l.UserID, l.PostID)
+	tx, err := lr.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Repeating the stored reaction takes it back.
+	res, err := tx.Exec(
+		`DELETE FROM likes WHERE post_id=$1 AND user_id=$2 AND status=$3`,
+		l.PostID, l.UserID, l.Status,
+	)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	var like *repo.Like
+	if removed > 0 {
+		err = addReactions(tx, l.PostID, l.Status, -1)
+	} else {
+		like, err = setReaction(tx, l.PostID, l.UserID, l.Status)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return like, nil
+}
+
+func (lr *likeRepo) SetReaction(postID, userID int64, status bool) (*repo.Like, error) {
+	tx, err := lr.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	like, err := setReaction(tx, postID, userID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return like, nil
+}
+
+// setReaction inserts the like or changes its status in one statement, so
+// concurrent calls for the same user and post never collide, and moves the
+// counters of the post by the change.
+func setReaction(tx *sqlx.Tx, postID, userID int64, status bool) (*repo.Like, error) {
+	var (
+		result   repo.Like
+		inserted bool
+	)
+
+	// Selecting from posts turns a missing post into no row. A stored like
+	// with the same status isn't updated and returns no row either, but it
+	// is locked all the same. xmax is zero only for inserted rows.
+	query := `
+		INSERT INTO likes(post_id, user_id, status)
+		SELECT id, $2, $3 FROM posts WHERE id=$1 AND deleted_at IS NULL
+		ON CONFLICT (post_id, user_id) DO UPDATE SET status=EXCLUDED.status
+		WHERE likes.status<>EXCLUDED.status
+		RETURNING id, user_id, post_id, status, created_at, xmax=0
+	`
+
+	err := tx.QueryRow(query, postID, userID, status).Scan(
+		&result.ID,
+		&result.UserID,
+		&result.PostID,
+		&result.Status,
+		&result.CreatedAt,
+		&inserted,
+	)
 	if errors.Is(err, sql.ErrNoRows) {
-		query := `
-			INSERT INTO likes(user_id, post_id, status) 
-			VALUES($1, $2, $3) RETURNING id
-		`
+		// Fails with sql.ErrNoRows again when the post doesn't exist.
+		return getLike(tx, userID, postID)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-		_, err := lr.db.Exec(query, l.UserID, l.PostID, l.Status)
-		if err != nil {
+	if err := addReactions(tx, postID, status, 1); err != nil {
+		return nil, err
+	}
+	if !inserted {
+		if err := addReactions(tx, postID, !status, -1); err != nil {
 			return nil, err
 		}
-	} else if like != nil {
-		if like.Status == l.Status {
-			_, err := lr.db.Exec(`DELETE FROM likes WHERE id=$1`, like.ID)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			query := `UPDATE likes SET status=$1 WHERE id=$2`
-			_, err := lr.db.Exec(query, l.Status, l.ID)
-			if err != nil {
-				return nil, err
-			}
-		}
 	}
 
-	return l, nil
+	return &result, nil
+}
+
+func (lr *likeRepo) RemoveReaction(postID, userID int64) error {
+	tx, err := lr.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status bool
+	err = tx.QueryRow(
+		`DELETE FROM likes WHERE post_id=$1 AND user_id=$2 RETURNING status`,
+		postID, userID,
+	).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := addReactions(tx, postID, status, -1); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// addReactions moves the like or dislike counter of the post by delta.
+// The post row stays locked until the transaction ends, so the likes
+// of a post are always locked before the post itself.
+func addReactions(tx *sqlx.Tx, postID int64, status bool, delta int) error {
+	column := "dislikes_count"
+	if status {
+		column = "likes_count"
+	}
+
+	_, err := tx.Exec("UPDATE posts SET "+column+"="+column+"+$1 WHERE id=$2", delta, postID)
+	return err
 }
 
 func (lr *likeRepo) Get(userID, postID int64) (*repo.Like, error) {
+	return getLike(lr.db, userID, postID)
+}
+
+func getLike(q sqlx.Queryer, userID, postID int64) (*repo.Like, error) {
 	var result repo.Like
 
 	query := `
@@ -56,16 +175,18 @@ func (lr *likeRepo) Get(userID, postID int64) (*repo.Like, error) {
 			id,
 			user_id,
 			post_id,
-			status
+			status,
+			created_at
 		FROM likes
 		WHERE user_id = $1 AND post_id = $2
 	`
 
-	err := lr.db.QueryRow(query, userID, postID).Scan(
+	err := q.QueryRowx(query, userID, postID).Scan(
 		&result.ID,
 		&result.UserID,
 		&result.PostID,
 		&result.Status,
+		&result.CreatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -78,11 +199,11 @@ func (lr *likeRepo) GetLikesDislikesCount(postID int64) (*repo.LikesDislikesCoun
 	var result repo.LikesDislikesCountsResult
 
 	query := `
-		SELECT 
-				COUNT(1) FILTER (WHERE status=true) as likes_count,
-				COUNT(1) FILTER (WHERE status=false) as dislikes_count
-		FROM likes
-		WHERE post_id=$1
+		SELECT
+			likes_count,
+			dislikes_count
+		FROM posts
+		WHERE id=$1 AND deleted_at IS NULL
 	`
 
 	row := lr.db.QueryRow(query, postID)
@@ -96,3 +217,177 @@ func (lr *likeRepo) GetLikesDislikesCount(postID int64) (*repo.LikesDislikesCoun
 
 	return &result, nil
 }
+
+var (
+	likeSortColumns = map[string]string{
+		"created_at": "created_at",
+		"id":         "id",
+	}
+	likedPostSortColumns = map[string]string{
+		"created_at": "lk.created_at",
+		"id":         "lk.id",
+	}
+)
+
+// reactionStatus returns the like status of a reaction, ok is false when
+// reaction doesn't filter the likes.
+func reactionStatus(reaction string) (status bool, ok bool, err error) {
+	switch reaction {
+	case "":
+		return false, false, nil
+	case repo.ReactionLike:
+		return true, true, nil
+	case repo.ReactionDislike:
+		return false, true, nil
+	}
+	return false, false, fmt.Errorf("%w: unknown reaction %q", repo.ErrInvalidArgument, reaction)
+}
+
+func (lr *likeRepo) GetLikers(params *repo.GetLikersParams) (*repo.GetLikersResult, error) {
+	result := repo.GetLikersResult{
+		Likes: make([]*repo.Like, 0),
+	}
+
+	status, filter, err := reactionStatus(params.Reaction)
+	if err != nil {
+		return nil, err
+	}
+
+	b := qb.New()
+	b.Where("post_id=?", params.PostID)
+	if filter {
+		b.Where("status=?", status)
+	}
+	for _, column := range []string{"created_at", "id"} {
+		if err := b.OrderBy(likeSortColumns, column, "desc"); err != nil {
+			return nil, err
+		}
+	}
+
+	// The count ignores the keyset so it is built before it is applied.
+	queryCount, countArgs := b.BuildCount("SELECT count(1) FROM likes")
+
+	applyKeyset(b, "created_at, id", "desc", keysetArgs(params.After), params.Limit, params.Page)
+
+	query, args := b.Build(`
+		SELECT
+			id,
+			user_id,
+			post_id,
+			status,
+			created_at
+		FROM likes`)
+
+	rows, err := lr.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var l repo.Like
+		err := rows.Scan(
+			&l.ID,
+			&l.UserID,
+			&l.PostID,
+			&l.Status,
+			&l.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result.Likes = append(result.Likes, &l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if params.Limit > 0 && len(result.Likes) > int(params.Limit) {
+		result.Likes = result.Likes[:params.Limit]
+		last := result.Likes[len(result.Likes)-1]
+		result.Next = &repo.Keyset{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		}
+	}
+
+	err = lr.db.QueryRow(queryCount, countArgs...).Scan(&result.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (lr *likeRepo) GetLikedPosts(params *repo.GetLikedPostsParams) (*repo.GetAllPostsResult, error) {
+	result := repo.GetAllPostsResult{
+		Posts: make([]*repo.Post, 0),
+	}
+
+	status, filter, err := reactionStatus(params.Reaction)
+	if err != nil {
+		return nil, err
+	}
+
+	b := qb.New()
+	b.Where("lk.user_id=?", params.UserID)
+	b.Where("posts.status=?", repo.PostStatusPublished)
+	b.Where("posts.deleted_at IS NULL")
+	if filter {
+		b.Where("lk.status=?", status)
+	}
+	for _, column := range []string{"created_at", "id"} {
+		if err := b.OrderBy(likedPostSortColumns, column, "desc"); err != nil {
+			return nil, err
+		}
+	}
+
+	from := `
+		FROM likes lk
+		INNER JOIN posts ON posts.id=lk.post_id`
+
+	// The count ignores the keyset so it is built before it is applied.
+	queryCount, countArgs := b.BuildCount("SELECT count(1)" + from)
+
+	applyKeyset(b, "lk.created_at, lk.id", "desc", keysetArgs(params.After), params.Limit, params.Page)
+
+	query, args := b.Build(`
+		SELECT
+			` + prefixColumns("posts", postColumns) + `,
+			` + viewerReactionColumn(viewerArg(b, params.ViewerID)) + `,
+			lk.created_at,
+			lk.id` + from)
+
+	rows, err := lr.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var last repo.Keyset
+	for rows.Next() {
+		var next repo.Keyset
+		p, err := scanPostWithReaction(rows, &next.CreatedAt, &next.ID)
+		if err != nil {
+			return nil, err
+		}
+		if params.Limit > 0 && len(result.Posts) == int(params.Limit) {
+			result.Next = &last
+			break
+		}
+		result.Posts = append(result.Posts, p)
+		last = next
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	err = lr.db.QueryRow(queryCount, countArgs...).Scan(&result.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
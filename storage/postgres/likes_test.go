@@ -1,19 +1,25 @@
 package postgres_test
 
 import (
+	"database/sql"
+	"sync"
 	"testing"
 
 	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func createLike(t *testing.T) *repo.Like {
+	p := createPost(t)
+
 	like, err := strg.Like().CreateOrUpdate(&repo.Like{
-		PostID: 6,
+		PostID: p.ID,
 		UserID: 2,
 		Status: true,
 	})
 	require.NoError(t, err)
+	require.NotNil(t, like)
 
 	return like
 }
@@ -27,5 +33,280 @@ func TestGetLike(t *testing.T) {
 	like2, err := strg.Like().Get(like.UserID, like.PostID)
 	require.NoError(t, err)
 	require.Equal(t, like.PostID, like2.PostID)
+}
+
+func TestCreateOrUpdateToggles(t *testing.T) {
+	like := createLike(t)
+
+	// The other reaction replaces the stored one.
+	disliked, err := strg.Like().CreateOrUpdate(&repo.Like{
+		PostID: like.PostID,
+		UserID: like.UserID,
+		Status: false,
+	})
+	require.NoError(t, err)
+	require.Equal(t, like.ID, disliked.ID)
+	require.False(t, disliked.Status)
+
+	stored, err := strg.Like().Get(like.UserID, like.PostID)
+	require.NoError(t, err)
+	require.False(t, stored.Status)
+
+	// The same reaction takes it back.
+	removed, err := strg.Like().CreateOrUpdate(&repo.Like{
+		PostID: like.PostID,
+		UserID: like.UserID,
+		Status: false,
+	})
+	require.NoError(t, err)
+	require.Nil(t, removed)
+
+	_, err = strg.Like().Get(like.UserID, like.PostID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestSetReaction(t *testing.T) {
+	p := createPost(t)
+
+	like, err := strg.Like().SetReaction(p.ID, 3, true)
+	require.NoError(t, err)
+	require.True(t, like.Status)
+
+	// Setting the stored reaction again keeps it.
+	like, err = strg.Like().SetReaction(p.ID, 3, true)
+	require.NoError(t, err)
+	require.True(t, like.Status)
+
+	like, err = strg.Like().SetReaction(p.ID, 3, false)
+	require.NoError(t, err)
+	require.False(t, like.Status)
+
+	require.NoError(t, strg.Like().RemoveReaction(p.ID, 3))
+	require.NoError(t, strg.Like().RemoveReaction(p.ID, 3))
+	_, err = strg.Like().Get(3, p.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = strg.Like().SetReaction(-1, 3, true)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestSetReactionConcurrently(t *testing.T) {
+	p := createPost(t)
+
+	const users = 40
+	var wg sync.WaitGroup
+	for i := 0; i < users; i++ {
+		wg.Add(1)
+		go func(userID int64) {
+			defer wg.Done()
+			// Every user changes their mind a few times and ends up
+			// liking the post when the id is even.
+			for j := 0; j < 3; j++ {
+				_, err := strg.Like().SetReaction(p.ID, userID, j%2 == 1)
+				assert.NoError(t, err)
+			}
+			_, err := strg.Like().SetReaction(p.ID, userID, userID%2 == 0)
+			assert.NoError(t, err)
+		}(int64(1000 + i))
+	}
+	wg.Wait()
+
+	counts, err := strg.Like().GetLikesDislikesCount(p.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(users/2), counts.LikesCount)
+	require.Equal(t, int64(users/2), counts.DislikesCount)
+}
+
+func TestCreateOrUpdateSameUserConcurrently(t *testing.T) {
+	p := createPost(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func(status bool) {
+			defer wg.Done()
+			_, err := strg.Like().CreateOrUpdate(&repo.Like{
+				PostID: p.ID,
+				UserID: 7,
+				Status: status,
+			})
+			assert.NoError(t, err)
+		}(i%2 == 0)
+	}
+	wg.Wait()
+
+	// However the toggles interleave, one user keeps at most one reaction.
+	likers, err := strg.Like().GetLikers(&repo.GetLikersParams{PostID: p.ID})
+	require.NoError(t, err)
+	require.LessOrEqual(t, likers.Count, int32(1))
+}
+
+func react(t *testing.T, postID, userID int64, status bool) {
+	_, err := strg.Like().CreateOrUpdate(&repo.Like{
+		PostID: postID,
+		UserID: userID,
+		Status: status,
+	})
+	require.NoError(t, err)
+}
+
+func TestGetLikers(t *testing.T) {
+	p := createPost(t)
+	react(t, p.ID, 101, true)
+	react(t, p.ID, 102, false)
+	react(t, p.ID, 103, true)
+
+	params := &repo.GetLikersParams{
+		PostID: p.ID,
+		Limit:  2,
+		Page:   1,
+	}
+	first, err := strg.Like().GetLikers(params)
+	require.NoError(t, err)
+	require.Len(t, first.Likes, 2)
+	require.Equal(t, int32(3), first.Count)
+	require.Equal(t, int64(103), first.Likes[0].UserID)
+	require.NotNil(t, first.Next)
+
+	params.After = first.Next
+	second, err := strg.Like().GetLikers(params)
+	require.NoError(t, err)
+	require.Len(t, second.Likes, 1)
+	require.Equal(t, int64(101), second.Likes[0].UserID)
+	require.Nil(t, second.Next)
+
+	dislikes, err := strg.Like().GetLikers(&repo.GetLikersParams{
+		PostID:   p.ID,
+		Reaction: repo.ReactionDislike,
+	})
+	require.NoError(t, err)
+	require.Len(t, dislikes.Likes, 1)
+	require.Equal(t, int64(102), dislikes.Likes[0].UserID)
+
+	_, err = strg.Like().GetLikers(&repo.GetLikersParams{
+		PostID:   p.ID,
+		Reaction: "love",
+	})
+	require.ErrorIs(t, err, repo.ErrInvalidArgument)
+}
+
+func TestGetLikedPosts(t *testing.T) {
+	liked := createPost(t)
+	disliked := createPost(t)
+	draft := createPost(t)
+	for _, p := range []*repo.Post{liked, disliked} {
+		_, err := strg.Post().UpdateStatus(p.ID, 1, repo.PostStatusPublished)
+		require.NoError(t, err)
+	}
+
+	react(t, liked.ID, 111, true)
+	react(t, disliked.ID, 111, false)
+	react(t, draft.ID, 111, true)
+	react(t, liked.ID, 112, true)
+
+	all, err := strg.Like().GetLikedPosts(&repo.GetLikedPostsParams{
+		UserID:   111,
+		ViewerID: 112,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), all.Count)
+	require.False(t, containsPost(all.Posts, draft.ID))
+	require.Equal(t, disliked.ID, all.Posts[0].ID)
+	require.Equal(t, liked.ID, all.Posts[1].ID)
+	require.Equal(t, int64(2), all.Posts[1].LikesCount)
+	require.Equal(t, repo.ReactionLike, all.Posts[1].ViewerReaction)
+	require.Empty(t, all.Posts[0].ViewerReaction)
+
+	likedOnly, err := strg.Like().GetLikedPosts(&repo.GetLikedPostsParams{
+		UserID:   111,
+		Reaction: repo.ReactionLike,
+		Limit:    1,
+		Page:     1,
+	})
+	require.NoError(t, err)
+	require.Len(t, likedOnly.Posts, 1)
+	require.Equal(t, liked.ID, likedOnly.Posts[0].ID)
+	require.Nil(t, likedOnly.Next)
+}
+
+func TestGetAllPostsLikes(t *testing.T) {
+	p := createPost(t)
+	_, err := strg.Post().UpdateStatus(p.ID, 1, repo.PostStatusPublished)
+	require.NoError(t, err)
+
+	react(t, p.ID, 121, true)
+	react(t, p.ID, 122, true)
+	react(t, p.ID, 123, false)
+
+	res, err := strg.Post().GetAll(&repo.GetAllPostsParams{
+		CategoryID: p.CategoryID,
+		ViewerID:   123,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Posts, 1)
+	require.Equal(t, int64(2), res.Posts[0].LikesCount)
+	require.Equal(t, int64(1), res.Posts[0].DislikesCount)
+	require.Equal(t, repo.ReactionDislike, res.Posts[0].ViewerReaction)
+
+	res, err = strg.Post().GetAll(&repo.GetAllPostsParams{
+		CategoryID: p.CategoryID,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Posts, 1)
+	require.Empty(t, res.Posts[0].ViewerReaction)
+}
 
+func TestPostCounters(t *testing.T) {
+	p := createPost(t)
+
+	_, err := strg.Like().SetReaction(p.ID, 131, true)
+	require.NoError(t, err)
+	_, err = strg.Like().SetReaction(p.ID, 132, true)
+	require.NoError(t, err)
+	_, err = strg.Like().SetReaction(p.ID, 132, true)
+	require.NoError(t, err)
+	_, err = strg.Like().SetReaction(p.ID, 131, false)
+	require.NoError(t, err)
+	_, err = strg.Like().CreateOrUpdate(&repo.Like{PostID: p.ID, UserID: 133, Status: false})
+	require.NoError(t, err)
+	require.NoError(t, strg.Like().RemoveReaction(p.ID, 132))
+
+	c := createComment(t, p.ID, 131)
+	createReply(t, c, 132)
+	createComment(t, p.ID, 133)
+	// A comment with replies turns into a tombstone, which isn't counted.
+	require.NoError(t, strg.Comment().Delete(c.ID, 131))
+
+	got, err := strg.Post().Get(p.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), got.LikesCount)
+	require.Equal(t, int64(2), got.DislikesCount)
+	require.Equal(t, int64(2), got.CommentsCount)
+
+	counts, err := strg.Like().GetLikesDislikesCount(p.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), counts.DislikesCount)
+}
+
+func TestRecountCounters(t *testing.T) {
+	p := createPost(t)
+	_, err := strg.Like().SetReaction(p.ID, 141, true)
+	require.NoError(t, err)
+	createComment(t, p.ID, 141)
+
+	ids, err := strg.Post().RecountCounters(p.ID)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	_, err = db.Exec("UPDATE posts SET likes_count=7, comments_count=0 WHERE id=$1", p.ID)
+	require.NoError(t, err)
+
+	ids, err = strg.Post().RecountCounters(0)
+	require.NoError(t, err)
+	require.Contains(t, ids, p.ID)
+
+	got, err := strg.Post().Get(p.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), got.LikesCount)
+	require.Equal(t, int64(1), got.CommentsCount)
 }
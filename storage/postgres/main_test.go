@@ -14,6 +14,7 @@ import (
 
 var (
 	strg storage.StorageI
+	db   *sqlx.DB
 )
 
 func TestMain(m *testing.M) {
@@ -28,7 +29,8 @@ func TestMain(m *testing.M) {
 		cfg.Postgres.Database,
 	)
 
-	db, err := sqlx.Open("postgres", connStr)
+	var err error
+	db, err = sqlx.Open("postgres", connStr)
 	if err != nil {
 		log.Fatalf("failed to open connection: %v", err)
 	}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/mirasildev/medium_post_service/storage/postgres/internal/qb"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+)
+
+// keysetOperator returns the row comparison which selects the rows
+// following a keyset in a list ordered by (created_at, id) in direction.
+func keysetOperator(direction string) string {
+	if strings.EqualFold(direction, "asc") {
+		return ">"
+	}
+	return "<"
+}
+
+// applyKeyset pages b after the keyset of the previous page or by offset
+// when there is none. It asks for one more row than limit, which tells
+// whether there is a next page. after holds the values of columns in the
+// last row of the previous page.
+func applyKeyset(b *qb.Builder, columns, direction string, after []interface{}, limit, page int32) {
+	if after != nil {
+		b.Where("("+columns+") "+keysetOperator(direction)+" (?, ?)", after...)
+		b.Limit(limit)
+	} else {
+		b.Page(limit, page)
+	}
+	if limit > 0 {
+		b.Limit(limit + 1)
+	}
+}
+
+// keysetArgs returns the (created_at, id) of the keyset, nil when there
+// is no keyset.
+func keysetArgs(k *repo.Keyset) []interface{} {
+	if k == nil {
+		return nil
+	}
+	return []interface{}{k.CreatedAt, k.ID}
+}
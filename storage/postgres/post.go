@@ -4,11 +4,51 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mirasildev/medium_post_service/pkg/slug"
+	"github.com/mirasildev/medium_post_service/storage/postgres/internal/qb"
 	"github.com/mirasildev/medium_post_service/storage/repo"
 )
 
+const postColumns = `
+			id,
+			title,
+			description,
+			image_url,
+			user_id,
+			category_id,
+			created_at,
+			updated_at,
+			views_count,
+			status,
+			published_at,
+			publish_at,
+			likes_count,
+			dislikes_count,
+			comments_count,
+			trending_score,
+			deleted_at,
+			public_id,
+			slug`
+
+const (
+	// searchConfig is the text search configuration of posts.search_vector.
+	searchConfig = "simple"
+
+	titleHeadlineOptions       = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+	descriptionHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=3, MaxWords=30, MinWords=10"
+
+	// trendingWindow is how long a post can trend after it is published,
+	// the score of an older post is close to zero anyway.
+	trendingWindow = 7 * 24 * time.Hour
+	// trendingGravity is how fast the score of a post decays with age.
+	trendingGravity = 1.8
+)
+
 type postRepo struct {
 	db *sqlx.DB
 }
@@ -20,127 +60,301 @@ func NewPost(db *sqlx.DB) repo.PostStorageI {
 }
 
 func (pr *postRepo) Create(post *repo.Post) (*repo.Post, error) {
+	return retryOnSlugConflict(func() (*repo.Post, error) {
+		return pr.create(post)
+	})
+}
+
+func (pr *postRepo) create(post *repo.Post) (*repo.Post, error) {
 	query := `
 		INSERT INTO posts(
 			title,
 			description,
 			image_url,
 			user_id,
-			category_id
-		) VALUES($1, $2, $3, $4, $5)
-		RETURNING id, created_at
+			category_id,
+			status,
+			published_at,
+			slug
+		) VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, public_id
 	`
 
-	row := pr.db.QueryRow(
+	if post.Status == "" {
+		post.Status = repo.PostStatusDraft
+	}
+	var publishedAt sql.NullTime
+	if post.Status == repo.PostStatusPublished {
+		post.PublishedAt = time.Now()
+		publishedAt = sql.NullTime{Time: post.PublishedAt, Valid: true}
+	}
+
+	tx, err := pr.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	post.Slug, err = uniquePostSlug(tx, 0, post.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	row := tx.QueryRow(
 		query,
 		post.Title,
 		post.Description,
 		post.ImageUrl,
 		post.UserID,
 		post.CategoryID,
+		post.Status,
+		publishedAt,
+		post.Slug,
 	)
 
-	err := row.Scan(
+	err = row.Scan(
 		&post.ID,
 		&post.CreatedAt,
+		&post.PublicID,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := insertPostRevision(tx, post, 0); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	return post, nil
 }
 
 func (pr *postRepo) Get(id int64) (*repo.Post, error) {
-	var (
-		result repo.Post
-		imageUrl sql.NullString
-		updatedAt sql.NullTime
-	)
+	query := `
+		SELECT
+			` + postColumns + `
+		FROM posts
+		WHERE id=$1 AND deleted_at IS NULL
+	`
+
+	return scanPost(pr.db.QueryRow(query, id))
+}
+
+func (pr *postRepo) GetBySlug(slug string) (*repo.Post, error) {
+	query := `
+		SELECT
+			` + postColumns + `
+		FROM posts
+		WHERE deleted_at IS NULL AND id=(
+			SELECT id FROM (
+				SELECT id, 0 AS previous FROM posts WHERE slug=$1
+				UNION ALL
+				SELECT post_id, 1 FROM post_slugs WHERE slug=$1
+			) matched
+			ORDER BY previous
+			LIMIT 1
+		)
+	`
+
+	return scanPost(pr.db.QueryRow(query, slug))
+}
+
+func (pr *postRepo) GetByPublicID(publicID string) (*repo.Post, error) {
+	query := `
+		SELECT
+			` + postColumns + `
+		FROM posts
+		WHERE public_id=$1 AND deleted_at IS NULL
+	`
+
+	p, err := scanPost(pr.db.QueryRow(query, publicID))
+	if isInvalidTextRepresentation(err) {
+		// Not a UUID, so no post has it.
+		return nil, sql.ErrNoRows
+	}
+	return p, err
+}
+
+func (pr *postRepo) AddViews(views map[int64]int64) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(views))
+	counts := make([]int64, 0, len(views))
+	for id, count := range views {
+		ids = append(ids, id)
+		counts = append(counts, count)
+	}
 
-	_, err := pr.db.Exec("UPDATE posts SET views_count=views_count+1 WHERE id=$1", id)
+	query := `
+		UPDATE posts p SET views_count=p.views_count+v.count
+		FROM unnest($1::INTEGER[], $2::INTEGER[]) AS v(id, count)
+		WHERE p.id=v.id
+	`
+	_, err := pr.db.Exec(query, pq.Array(ids), pq.Array(counts))
+	return err
+}
+
+func (pr *postRepo) RecountCounters(postID int64) ([]int64, error) {
+	tx, err := pr.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Locking the posts first waits for the likes and comments which are
+	// being counted and holds back the new ones until the counts are
+	// stored, so the recount can't race with them.
+	_, err = tx.Exec("SELECT 1 FROM posts WHERE $1::INTEGER=0 OR id=$1 FOR UPDATE", postID)
 	if err != nil {
 		return nil, err
 	}
 
 	query := `
-		SELECT
-			id,
-			title,
-			description,
-			image_url,
-			user_id,
-			category_id,
-			created_at,
-			updated_at,
-			views_count
-		FROM posts
-		WHERE id=$1
+		UPDATE posts p SET
+			likes_count=c.likes_count,
+			dislikes_count=c.dislikes_count,
+			comments_count=c.comments_count
+		FROM (
+			SELECT
+				id,
+				(SELECT count(1) FROM likes l WHERE l.post_id=posts.id AND l.status) AS likes_count,
+				(SELECT count(1) FROM likes l WHERE l.post_id=posts.id AND NOT l.status) AS dislikes_count,
+				(SELECT count(1) FROM comments cm WHERE cm.post_id=posts.id AND cm.deleted_at IS NULL) AS comments_count
+			FROM posts
+			WHERE $1::INTEGER=0 OR id=$1
+		) c
+		WHERE p.id=c.id AND (p.likes_count, p.dislikes_count, p.comments_count)
+			IS DISTINCT FROM (c.likes_count, c.dislikes_count, c.comments_count)
+		RETURNING p.id
 	`
 
-	row := pr.db.QueryRow(query, id)
-	err = row.Scan(
-		&result.ID,
-		&result.Title,
-		&result.Description,
-		&imageUrl,
-		&result.UserID,
-		&result.CategoryID,
-		&result.CreatedAt,
-		&updatedAt,
-		&result.ViewsCount,
-	)
+	rows, err := tx.Query(query, postID)
 	if err != nil {
 		return nil, err
 	}
-	result.ImageUrl = imageUrl.String
-	result.UpdatedAt = updatedAt.Time
+	defer rows.Close()
 
-	return &result, nil
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (pr *postRepo) UpdateTrendingScores(now time.Time) (int64, error) {
+	// The points of a post are divided by its age in hours, plus two so
+	// that new posts don't shoot up, to the power of the gravity. Posts
+	// which left the window are reset once.
+	query := `
+		UPDATE posts SET trending_score=CASE
+			WHEN status=$2 AND published_at > $3 THEN (
+				GREATEST(likes_count - dislikes_count, 0) + 2 * comments_count + views_count / 10.0
+			) / power(GREATEST(EXTRACT(EPOCH FROM $1::TIMESTAMPTZ - published_at) / 3600, 0) + 2, $4::FLOAT8)
+			ELSE 0
+		END
+		WHERE (status=$2 AND published_at > $3) OR trending_score<>0
+	`
+
+	res, err := pr.db.Exec(query, now, repo.PostStatusPublished, now.Add(-trendingWindow), trendingGravity)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
 }
 
+var (
+	postSortColumns = map[string]string{
+		"created_at":     "created_at",
+		"views_count":    "views_count",
+		"likes_count":    "likes_count",
+		"comments_count": "comments_count",
+		"trending_score": "trending_score",
+		"id":             "id",
+	}
+	searchSortColumns = map[string]string{
+		"rank":       "rank",
+		"created_at": "created_at",
+		"id":         "id",
+	}
+)
+
 func (pr *postRepo) GetAll(params *repo.GetAllPostsParams) (*repo.GetAllPostsResult, error) {
 	result := repo.GetAllPostsResult{
 		Posts: make([]*repo.Post, 0),
 	}
 
-	offset := (params.Page - 1) * params.Limit
-
-	limit := fmt.Sprintf(" LIMIT %d OFFSET %d ", params.Limit, offset)
+	b := qb.New()
+	b.Where("deleted_at IS NULL")
+	if params.IncludeDrafts && params.UserID != 0 {
+		b.Where("status IN (?, ?)", repo.PostStatusPublished, repo.PostStatusDraft)
+	} else {
+		b.Where("status=?", repo.PostStatusPublished)
+	}
 
-	filter := "WHERE true"
 	if params.Search != "" {
-		filter += " AND title ilike '%" + params.Search + "%' "
+		b.Where("search_vector @@ websearch_to_tsquery('"+searchConfig+"', ?)", params.Search)
 	}
 
 	if params.CategoryID != 0 {
-		filter += fmt.Sprintf(" AND category_id=%d ", params.CategoryID)
+		if params.IncludeSubcategories {
+			b.Where("category_id IN (" + categorySubtree(b.Arg(params.CategoryID)) + ")")
+		} else {
+			b.Where("category_id=?", params.CategoryID)
+		}
 	}
 
 	if params.UserID != 0 {
-		filter += fmt.Sprintf(" AND user_id=%d ", params.UserID)
+		b.Where("user_id=?", params.UserID)
 	}
 
-	orderBy := " ORDER BY created_at desc "
-	if params.SortByDate != "" {
-		orderBy = fmt.Sprintf(" ORDER BY created_at %s ", params.SortByDate)
+	if len(params.Tags) > 0 {
+		b.Where(`EXISTS (
+			SELECT 1 FROM post_tags pt
+			INNER JOIN tags t ON t.id=pt.tag_id
+			WHERE pt.post_id=posts.id AND t.name=ANY(?::VARCHAR[])
+		)`, pq.Array(params.Tags))
 	}
 
-	query := `
+	sort, err := resolvePostSort(params.Sort, params.SortByDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, column := range []string{sort.column, "id"} {
+		if err := b.OrderBy(postSortColumns, column, sort.direction); err != nil {
+			return nil, fmt.Errorf("%w: %v", repo.ErrInvalidArgument, err)
+		}
+	}
+
+	// The count ignores the keyset so it is built before it is applied.
+	queryCount, countArgs := b.BuildCount(`SELECT count(1) FROM posts`)
+
+	applyKeyset(b, sort.column+", id", sort.direction, sort.keyset(params.After), params.Limit, params.Page)
+
+	// The viewer is bound after the count, which doesn't reference it.
+	query, args := b.Build(`
 		SELECT
-			id,
-			title,
-			description,
-			image_url,
-			user_id,
-			category_id,
-			created_at,
-			updated_at,
-			views_count
-		FROM posts
-		` + filter + orderBy + limit
+			` + postColumns + `,
+			` + viewerReactionColumn(viewerArg(b, params.ViewerID)) + `
+		FROM posts`)
 
-	rows, err := pr.db.Query(query)
+	rows, err := pr.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -148,33 +362,152 @@ func (pr *postRepo) GetAll(params *repo.GetAllPostsParams) (*repo.GetAllPostsRes
 	defer rows.Close()
 
 	for rows.Next() {
-		var (
-			p         repo.Post
-			updatedAt sql.NullTime
-			imageUrl  sql.NullString
-		)
+		p, err := scanPostWithReaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		result.Posts = append(result.Posts, p)
+	}
 
-		err := rows.Scan(
-			&p.ID,
-			&p.Title,
-			&p.Description,
-			&imageUrl,
-			&p.UserID,
-			&p.CategoryID,
-			&p.CreatedAt,
-			&updatedAt,
-			&p.ViewsCount,
-		)
+	if params.Limit > 0 && len(result.Posts) > int(params.Limit) {
+		result.Posts = result.Posts[:params.Limit]
+		result.Next = sort.next(result.Posts[len(result.Posts)-1])
+	}
+
+	err = pr.db.QueryRow(queryCount, countArgs...).Scan(&result.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// postSort is an order of GetAll. The keyset pairs column with id, the
+// value of column is taken from the post by value, or from CreatedAt when
+// value is nil.
+type postSort struct {
+	column    string
+	direction string
+	value     func(p *repo.Post) float64
+}
+
+var postSorts = map[string]postSort{
+	repo.PostSortNewest: {column: "created_at", direction: "desc"},
+	repo.PostSortOldest: {column: "created_at", direction: "asc"},
+	repo.PostSortMostViewed: {
+		column:    "views_count",
+		direction: "desc",
+		value:     func(p *repo.Post) float64 { return float64(p.ViewsCount) },
+	},
+	repo.PostSortMostLiked: {
+		column:    "likes_count",
+		direction: "desc",
+		value:     func(p *repo.Post) float64 { return float64(p.LikesCount) },
+	},
+	repo.PostSortMostCommented: {
+		column:    "comments_count",
+		direction: "desc",
+		value:     func(p *repo.Post) float64 { return float64(p.CommentsCount) },
+	},
+	repo.PostSortTrending: {
+		column:    "trending_score",
+		direction: "desc",
+		value:     func(p *repo.Post) float64 { return p.TrendingScore },
+	},
+}
+
+func resolvePostSort(sort, sortByDate string) (postSort, error) {
+	if sort == "" {
+		switch strings.ToLower(sortByDate) {
+		case "", "desc":
+			sort = repo.PostSortNewest
+		case "asc":
+			sort = repo.PostSortOldest
+		default:
+			return postSort{}, fmt.Errorf("%w: unknown sort_by_date %q", repo.ErrInvalidArgument, sortByDate)
+		}
+	}
+
+	s, ok := postSorts[sort]
+	if !ok {
+		return postSort{}, fmt.Errorf("%w: unknown sort %q", repo.ErrInvalidArgument, sort)
+	}
+	return s, nil
+}
+
+func (s postSort) keyset(after *repo.Keyset) []interface{} {
+	if after == nil || s.value == nil {
+		return keysetArgs(after)
+	}
+	return []interface{}{after.Value, after.ID}
+}
+
+func (s postSort) next(last *repo.Post) *repo.Keyset {
+	k := &repo.Keyset{
+		CreatedAt: last.CreatedAt,
+		ID:        last.ID,
+	}
+	if s.value != nil {
+		k.Value = s.value(last)
+	}
+	return k
+}
+
+func (pr *postRepo) Search(params *repo.SearchPostsParams) (*repo.SearchPostsResult, error) {
+	result := repo.SearchPostsResult{
+		Results: make([]*repo.SearchPostResult, 0),
+	}
+
+	b := qb.New()
+	tsquery := "websearch_to_tsquery('" + searchConfig + "', " + b.Arg(params.Query) + ")"
+	b.Where("status=?", repo.PostStatusPublished)
+	b.Where("deleted_at IS NULL")
+	b.Where("search_vector @@ " + tsquery)
+	if params.CategoryID != 0 {
+		b.Where("category_id=?", params.CategoryID)
+	}
+	for _, column := range []string{"rank", "created_at", "id"} {
+		if err := b.OrderBy(searchSortColumns, column, "desc"); err != nil {
+			return nil, err
+		}
+	}
+	b.Page(params.Limit, params.Page)
+
+	// Headlines are expensive, so they are built only for the page of
+	// posts which was already ranked and limited.
+	inner, args := b.Build(`
+			SELECT
+				` + postColumns + `,
+				ts_rank(search_vector, ` + tsquery + `) AS rank
+			FROM posts`)
+	query := `
+		SELECT
+			` + postColumns + `,
+			rank,
+			ts_headline('` + searchConfig + `', title, ` + tsquery + `, '` + titleHeadlineOptions + `'),
+			ts_headline('` + searchConfig + `', description, ` + tsquery + `, '` + descriptionHeadlineOptions + `')
+		FROM (` + inner + `) ranked
+		ORDER BY rank DESC, created_at DESC, id DESC
+	`
+
+	rows, err := pr.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r repo.SearchPostResult
+
+		r.Post, err = scanPost(rows, &r.Rank, &r.TitleHighlight, &r.DescriptionHighlight)
 		if err != nil {
 			return nil, err
 		}
-		p.ImageUrl = imageUrl.String
-		p.UpdatedAt = updatedAt.Time
-		result.Posts = append(result.Posts, &p)
+		result.Results = append(result.Results, &r)
 	}
 
-	queryCount := `SELECT count(1) FROM posts ` + filter
-	err = pr.db.QueryRow(queryCount).Scan(&result.Count)
+	queryCount, args := b.BuildCount(`SELECT count(1) FROM posts`)
+	err = pr.db.QueryRow(queryCount, args...).Scan(&result.Count)
 	if err != nil {
 		return nil, err
 	}
@@ -183,61 +516,530 @@ func (pr *postRepo) GetAll(params *repo.GetAllPostsParams) (*repo.GetAllPostsRes
 }
 
 func (pr *postRepo) UpdatePost(post *repo.Post) (*repo.Post, error) {
+	return retryOnSlugConflict(func() (*repo.Post, error) {
+		return pr.updatePost(post)
+	})
+}
+
+func (pr *postRepo) updatePost(post *repo.Post) (*repo.Post, error) {
 	query := `
 		UPDATE posts SET
 			title=$1,
 			description=$2,
 			image_url=$3,
 			category_id=$4,
-			updated_at=$5
-		WHERE id=$6 AND user_id=$7
-		RETURNING id, title, description, image_url, user_id, category_id, created_at, updated_at, views_count
+			updated_at=CURRENT_TIMESTAMP
+		WHERE id=$5 AND user_id=$6 AND deleted_at IS NULL
+		RETURNING ` + postColumns + `
 	`
-	var result repo.Post
-	err := pr.db.QueryRow(
+
+	tx, err := pr.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	oldTitle, oldDescription, err := lockPostText(tx, post.ID, post.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := scanPost(tx.QueryRow(
 		query,
 		post.Title,
 		post.Description,
 		post.ImageUrl,
 		post.CategoryID,
-		post.UpdatedAt,
-		post.UserID,
 		post.ID,
-	).Scan(
+		post.UserID,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := updatePostSlug(tx, result, oldTitle); err != nil {
+		return nil, err
+	}
+
+	// Only the title and description are versioned.
+	if result.Title != oldTitle || result.Description != oldDescription {
+		if err := insertPostRevision(tx, result, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (pr *postRepo) RestoreRevision(postID, userID int64, revision int32) (*repo.Post, error) {
+	return retryOnSlugConflict(func() (*repo.Post, error) {
+		return pr.restoreRevision(postID, userID, revision)
+	})
+}
+
+func (pr *postRepo) restoreRevision(postID, userID int64, revision int32) (*repo.Post, error) {
+	query := `
+		UPDATE posts SET
+			title=r.title,
+			description=r.description,
+			updated_at=CURRENT_TIMESTAMP
+		FROM post_revisions r
+		WHERE posts.id=$1 AND posts.user_id=$2 AND posts.deleted_at IS NULL
+			AND r.post_id=posts.id AND r.revision=$3
+		RETURNING ` + prefixColumns("posts", postColumns)
+
+	tx, err := pr.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	oldTitle, _, err := lockPostText(tx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := scanPost(tx.QueryRow(query, postID, userID, revision))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := updatePostSlug(tx, result, oldTitle); err != nil {
+		return nil, err
+	}
+
+	if err := insertPostRevision(tx, result, revision); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (pr *postRepo) DeletePost(id int64, UserID int64) error {
+	query := `
+		UPDATE posts SET deleted_at=CURRENT_TIMESTAMP
+		WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL
+	`
+	result, err := pr.db.Exec(query, id, UserID)
+	if err != nil {
+		return err
+	}
+
+	rowsCount, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsCount == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (pr *postRepo) RestorePost(id, userID int64) (*repo.Post, error) {
+	query := `
+		UPDATE posts SET deleted_at=NULL
+		WHERE id=$1 AND user_id=$2 AND deleted_at IS NOT NULL
+		RETURNING ` + postColumns
+
+	return scanPost(pr.db.QueryRow(query, id, userID))
+}
+
+var trashSortColumns = map[string]string{
+	"deleted_at": "deleted_at",
+	"id":         "id",
+}
+
+func (pr *postRepo) GetTrash(params *repo.GetTrashParams) (*repo.GetAllPostsResult, error) {
+	result := repo.GetAllPostsResult{
+		Posts: make([]*repo.Post, 0),
+	}
+
+	b := qb.New()
+	b.Where("deleted_at IS NOT NULL")
+	b.Where("user_id=?", params.UserID)
+	for _, column := range []string{"deleted_at", "id"} {
+		if err := b.OrderBy(trashSortColumns, column, "desc"); err != nil {
+			return nil, err
+		}
+	}
+
+	// The count ignores the keyset so it is built before it is applied.
+	queryCount, countArgs := b.BuildCount("SELECT count(1) FROM posts")
+
+	// The CreatedAt of the keyset holds deleted_at.
+	applyKeyset(b, "deleted_at, id", "desc", keysetArgs(params.After), params.Limit, 1)
+
+	query, args := b.Build(`
+		SELECT
+			` + postColumns + `
+		FROM posts`)
+
+	rows, err := pr.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := scanPost(rows)
+		if err != nil {
+			return nil, err
+		}
+		result.Posts = append(result.Posts, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if params.Limit > 0 && len(result.Posts) > int(params.Limit) {
+		result.Posts = result.Posts[:params.Limit]
+		last := result.Posts[len(result.Posts)-1]
+		result.Next = &repo.Keyset{
+			CreatedAt: last.DeletedAt,
+			ID:        last.ID,
+		}
+	}
+
+	err = pr.db.QueryRow(queryCount, countArgs...).Scan(&result.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (pr *postRepo) PurgeDeleted(before time.Time, limit int) ([]int64, error) {
+	tx, err := pr.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT id FROM posts
+		WHERE deleted_at < $1
+		ORDER BY deleted_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+	rows, err := tx.Query(query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	// Comments and likes don't cascade, the other tables referencing
+	// posts do.
+	for _, query := range []string{
+		"DELETE FROM likes WHERE post_id=ANY($1)",
+		"DELETE FROM comments WHERE post_id=ANY($1)",
+		"DELETE FROM posts WHERE id=ANY($1)",
+	} {
+		if _, err := tx.Exec(query, pq.Array(ids)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (pr *postRepo) UpdateStatus(id, userID int64, status string) (*repo.Post, error) {
+	query := `
+		UPDATE posts SET
+			status=$1,
+			published_at=CASE WHEN $4
+				THEN COALESCE(published_at, CURRENT_TIMESTAMP)
+				ELSE published_at END,
+			publish_at=NULL,
+			updated_at=CURRENT_TIMESTAMP
+		WHERE id=$2 AND user_id=$3 AND deleted_at IS NULL
+		RETURNING ` + postColumns
+
+	return scanPost(pr.db.QueryRow(query, status, id, userID, status == repo.PostStatusPublished))
+}
+
+func (pr *postRepo) SchedulePublish(id, userID int64, publishAt time.Time) (*repo.Post, error) {
+	query := `
+		UPDATE posts SET
+			publish_at=$1,
+			updated_at=CURRENT_TIMESTAMP
+		WHERE id=$2 AND user_id=$3 AND status=$4 AND publish_at IS NULL
+			AND deleted_at IS NULL
+		RETURNING ` + postColumns
+
+	return scanPost(pr.db.QueryRow(query, publishAt, id, userID, repo.PostStatusDraft))
+}
+
+func (pr *postRepo) ReschedulePublish(id, userID int64, publishAt time.Time) (*repo.Post, error) {
+	query := `
+		UPDATE posts SET
+			publish_at=$1,
+			updated_at=CURRENT_TIMESTAMP
+		WHERE id=$2 AND user_id=$3 AND status=$4 AND publish_at IS NOT NULL
+			AND deleted_at IS NULL
+		RETURNING ` + postColumns
+
+	return scanPost(pr.db.QueryRow(query, publishAt, id, userID, repo.PostStatusDraft))
+}
+
+func (pr *postRepo) CancelScheduledPublish(id, userID int64) (*repo.Post, error) {
+	query := `
+		UPDATE posts SET
+			publish_at=NULL,
+			updated_at=CURRENT_TIMESTAMP
+		WHERE id=$1 AND user_id=$2 AND status=$3 AND publish_at IS NOT NULL
+			AND deleted_at IS NULL
+		RETURNING ` + postColumns
+
+	return scanPost(pr.db.QueryRow(query, id, userID, repo.PostStatusDraft))
+}
+
+func (pr *postRepo) PublishDue(now time.Time, limit int) ([]*repo.Post, error) {
+	query := `
+		WITH due AS (
+			SELECT id FROM posts
+			WHERE status=$1 AND publish_at <= $2 AND deleted_at IS NULL
+			ORDER BY publish_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE posts SET
+			status=$4,
+			published_at=posts.publish_at,
+			publish_at=NULL
+		FROM due
+		WHERE posts.id=due.id
+		RETURNING ` + prefixColumns("posts", postColumns)
+
+	rows, err := pr.db.Query(query, repo.PostStatusDraft, now, limit, repo.PostStatusPublished)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*repo.Post, 0)
+	for rows.Next() {
+		p, err := scanPost(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+
+	return result, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanPost scans a row selected with postColumns, extra holds the
+// destinations of the columns selected after them.
+func scanPost(row scanner, extra ...interface{}) (*repo.Post, error) {
+	var (
+		result      repo.Post
+		imageUrl    sql.NullString
+		updatedAt   sql.NullTime
+		publishedAt sql.NullTime
+		publishAt   sql.NullTime
+		deletedAt   sql.NullTime
+	)
+
+	dest := []interface{}{
 		&result.ID,
 		&result.Title,
 		&result.Description,
-		&result.ImageUrl,
+		&imageUrl,
 		&result.UserID,
 		&result.CategoryID,
 		&result.CreatedAt,
-		&result.UpdatedAt,
+		&updatedAt,
 		&result.ViewsCount,
-	)
+		&result.Status,
+		&publishedAt,
+		&publishAt,
+		&result.LikesCount,
+		&result.DislikesCount,
+		&result.CommentsCount,
+		&result.TrendingScore,
+		&deletedAt,
+		&result.PublicID,
+		&result.Slug,
+	}
+
+	err := row.Scan(append(dest, extra...)...)
 	if err != nil {
 		return nil, err
 	}
+	result.ImageUrl = imageUrl.String
+	result.UpdatedAt = updatedAt.Time
+	result.PublishedAt = publishedAt.Time
+	result.PublishAt = publishAt.Time
+	result.DeletedAt = deletedAt.Time
 
 	return &result, nil
 }
 
-func (pr *postRepo) DeletePost(id int64, UserID int64) error {
+// viewerReactionColumn selects the status of the like of the viewer,
+// which is a placeholder or NULL.
+func viewerReactionColumn(viewer string) string {
+	return `(SELECT l.status FROM likes l WHERE l.post_id=posts.id AND l.user_id=` + viewer + `) AS viewer_status`
+}
 
-	query := "DELETE FROM posts WHERE id=$1 AND user_id=$2"
-	result, err := pr.db.Exec(query, id, UserID)
+func viewerArg(b *qb.Builder, viewerID int64) string {
+	if viewerID == 0 {
+		return "NULL::INTEGER"
+	}
+	return b.Arg(viewerID)
+}
+
+// scanPostWithReaction scans a row selected with viewerReactionColumn.
+func scanPostWithReaction(row scanner, extra ...interface{}) (*repo.Post, error) {
+	var viewerStatus sql.NullBool
+
+	p, err := scanPost(row, append([]interface{}{&viewerStatus}, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	if viewerStatus.Valid {
+		p.ViewerReaction = repo.LikeReaction(viewerStatus.Bool)
+	}
+
+	return p, nil
+}
+
+// prefixColumns qualifies every column of a column list with the table alias.
+func prefixColumns(alias, columns string) string {
+	parts := strings.Split(columns, ",")
+	for i, c := range parts {
+		parts[i] = alias + "." + strings.TrimSpace(c)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// slugConflictAttempts is how many times a write is tried when a
+// concurrent one takes the same slug.
+const slugConflictAttempts = 3
+
+// retryOnSlugConflict runs fn again when the slug it picked was taken
+// after it checked it.
+func retryOnSlugConflict(fn func() (*repo.Post, error)) (*repo.Post, error) {
+	for attempt := 1; ; attempt++ {
+		p, err := fn()
+		if attempt < slugConflictAttempts && isSlugConflict(err) {
+			continue
+		}
+		return p, err
+	}
+}
+
+func isSlugConflict(err error) bool {
+	var pqErr *pq.Error
+	return isUniqueViolation(err) && errors.As(err, &pqErr) && pqErr.Constraint == "posts_slug_idx"
+}
+
+// lockPostText locks the post of the user until the end of tx and returns
+// its title and description.
+func lockPostText(tx *sqlx.Tx, id, userID int64) (title, description string, err error) {
+	err = tx.QueryRow(
+		"SELECT title, description FROM posts WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL FOR UPDATE",
+		id, userID,
+	).Scan(&title, &description)
+	return title, description, err
+}
+
+// uniquePostSlug makes a slug from the title which no other post uses now
+// or used before. postID is 0 for a new post.
+func uniquePostSlug(tx *sqlx.Tx, postID int64, title string) (string, error) {
+	base := slug.Make(title)
+	if base == "" {
+		base = "post"
+	}
+
+	query := `
+		SELECT EXISTS (SELECT 1 FROM posts WHERE slug=$1 AND id<>$2)
+			OR EXISTS (SELECT 1 FROM post_slugs WHERE slug=$1 AND post_id<>$2)
+	`
+
+	candidate := base
+	for n := 2; ; n++ {
+		var taken bool
+		if err := tx.QueryRow(query, candidate, postID).Scan(&taken); err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = slug.WithSuffix(base, n)
+	}
+}
+
+// updatePostSlug gives the post a new slug when its title changed enough
+// to change the slug, the current one is kept to redirect to the post.
+func updatePostSlug(tx *sqlx.Tx, p *repo.Post, oldTitle string) error {
+	if slug.Make(p.Title) == slug.Make(oldTitle) {
+		return nil
+	}
+
+	newSlug, err := uniquePostSlug(tx, p.ID, p.Title)
 	if err != nil {
 		return err
 	}
+	if newSlug == p.Slug {
+		return nil
+	}
 
-	rowsCount, err := result.RowsAffected()
+	query := `
+		INSERT INTO post_slugs(slug, post_id) VALUES($1, $2)
+		ON CONFLICT (slug) DO NOTHING
+	`
+	if _, err := tx.Exec(query, p.Slug, p.ID); err != nil {
+		return err
+	}
+
+	// The post may be getting back one of its previous slugs.
+	_, err = tx.Exec("DELETE FROM post_slugs WHERE slug=$1 AND post_id=$2", newSlug, p.ID)
 	if err != nil {
 		return err
 	}
 
-	if rowsCount == 0 {
-		return errors.New("you can't delete other user's post")
-		// return sql.ErrNoRows
+	_, err = tx.Exec("UPDATE posts SET slug=$1 WHERE id=$2", newSlug, p.ID)
+	if err != nil {
+		return err
 	}
+	p.Slug = newSlug
 
 	return nil
 }
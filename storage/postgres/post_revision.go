@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/mirasildev/medium_post_service/pkg/utils"
+	"github.com/mirasildev/medium_post_service/storage/postgres/internal/qb"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+)
+
+type postRevisionRepo struct {
+	db *sqlx.DB
+}
+
+func NewPostRevision(db *sqlx.DB) repo.PostRevisionStorageI {
+	return &postRevisionRepo{
+		db: db,
+	}
+}
+
+const postRevisionColumns = `
+			id,
+			post_id,
+			revision,
+			title,
+			description,
+			user_id,
+			restored_from,
+			created_at`
+
+var postRevisionSortColumns = map[string]string{
+	"revision": "revision",
+}
+
+func (rr *postRevisionRepo) Get(postID int64, revision int32) (*repo.PostRevision, error) {
+	query := `
+		SELECT
+			` + postRevisionColumns + `
+		FROM post_revisions
+		WHERE post_id=$1 AND revision=$2
+	`
+
+	return scanPostRevision(rr.db.QueryRow(query, postID, revision))
+}
+
+func (rr *postRevisionRepo) GetAll(params *repo.GetAllPostRevisionsParams) (*repo.GetAllPostRevisionsResult, error) {
+	result := repo.GetAllPostRevisionsResult{
+		Revisions: make([]*repo.PostRevision, 0),
+	}
+
+	b := qb.New().Where("post_id=?", params.PostID)
+	countQuery, countArgs := b.BuildCount("SELECT count(1) FROM post_revisions")
+
+	if err := b.OrderBy(postRevisionSortColumns, "revision", "desc"); err != nil {
+		return nil, err
+	}
+	b.Page(params.Limit, params.Page)
+
+	query, args := b.Build(`
+		SELECT
+			` + postRevisionColumns + `
+		FROM post_revisions`)
+
+	rows, err := rr.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		r, err := scanPostRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		result.Revisions = append(result.Revisions, r)
+	}
+
+	err = rr.db.QueryRow(countQuery, countArgs...).Scan(&result.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// insertPostRevision stores the current title and description of the post
+// as its next revision. It must run in the transaction which changed the
+// post, the row lock taken by that change serializes revision numbers.
+func insertPostRevision(tx *sqlx.Tx, post *repo.Post, restoredFrom int32) error {
+	query := `
+		INSERT INTO post_revisions(
+			post_id,
+			revision,
+			title,
+			description,
+			user_id,
+			restored_from
+		) SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3, $4, $5
+		FROM post_revisions WHERE post_id=$1
+	`
+
+	_, err := tx.Exec(
+		query,
+		post.ID,
+		post.Title,
+		post.Description,
+		post.UserID,
+		utils.NullInt32(restoredFrom),
+	)
+	return err
+}
+
+func scanPostRevision(row scanner) (*repo.PostRevision, error) {
+	var (
+		result       repo.PostRevision
+		restoredFrom sql.NullInt32
+	)
+
+	err := row.Scan(
+		&result.ID,
+		&result.PostID,
+		&result.Revision,
+		&result.Title,
+		&result.Description,
+		&result.UserID,
+		&restoredFrom,
+		&result.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	result.RestoredFrom = restoredFrom.Int32
+
+	return &result, nil
+}
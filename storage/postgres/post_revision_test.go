@@ -0,0 +1,75 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/bxcodec/faker/v4"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePostWritesRevision(t *testing.T) {
+	p := createPost(t)
+
+	revision, err := strg.PostRevision().Get(p.ID, 1)
+	require.NoError(t, err)
+	require.Equal(t, p.Title, revision.Title)
+	require.Equal(t, p.Description, revision.Description)
+}
+
+func TestUpdatePostWritesRevision(t *testing.T) {
+	p := createPost(t)
+
+	p.Title = faker.Sentence()
+	updated, err := strg.Post().UpdatePost(p)
+	require.NoError(t, err)
+	require.Equal(t, p.Title, updated.Title)
+
+	result, err := strg.PostRevision().GetAll(&repo.GetAllPostRevisionsParams{
+		PostID: p.ID,
+		Limit:  10,
+		Page:   1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), result.Count)
+	require.Equal(t, int32(2), result.Revisions[0].Revision)
+	require.Equal(t, p.Title, result.Revisions[0].Title)
+}
+
+func TestRestoreRevision(t *testing.T) {
+	p := createPost(t)
+	original := p.Title
+
+	p.Title = faker.Sentence()
+	_, err := strg.Post().UpdatePost(p)
+	require.NoError(t, err)
+
+	restored, err := strg.Post().RestoreRevision(p.ID, p.UserID, 1)
+	require.NoError(t, err)
+	require.Equal(t, original, restored.Title)
+
+	revision, err := strg.PostRevision().Get(p.ID, 3)
+	require.NoError(t, err)
+	require.Equal(t, original, revision.Title)
+	require.Equal(t, int32(1), revision.RestoredFrom)
+
+	_, err = strg.Post().RestoreRevision(p.ID, p.UserID+1, 1)
+	require.Error(t, err)
+}
+
+func TestUpdatePostWithoutTextChangesSkipsRevision(t *testing.T) {
+	p := createPost(t)
+
+	p.ImageUrl = faker.URL()
+	_, err := strg.Post().UpdatePost(p)
+	require.NoError(t, err)
+
+	// Page 0 is read as the first page.
+	result, err := strg.PostRevision().GetAll(&repo.GetAllPostRevisionsParams{
+		PostID: p.ID,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), result.Count)
+	require.Len(t, result.Revisions, 1)
+}
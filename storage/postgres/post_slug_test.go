@@ -0,0 +1,80 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/bxcodec/faker/v4"
+	"github.com/mirasildev/medium_post_service/pkg/slug"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func createPostWithTitle(t *testing.T, title string) *repo.Post {
+	c := createCategory(t)
+
+	post, err := strg.Post().Create(&repo.Post{
+		Title:       title,
+		Description: faker.Paragraph(),
+		UserID:      1,
+		CategoryID:  c.ID,
+	})
+	require.NoError(t, err)
+	return post
+}
+
+func TestPostSlugs(t *testing.T) {
+	title := faker.Sentence()
+	first := createPostWithTitle(t, title)
+	second := createPostWithTitle(t, title)
+
+	require.Equal(t, slug.Make(title), first.Slug)
+	require.Equal(t, slug.WithSuffix(first.Slug, 2), second.Slug)
+	require.NotEmpty(t, first.PublicID)
+	require.NotEqual(t, first.PublicID, second.PublicID)
+
+	oldSlug := first.Slug
+	first.Title = faker.Sentence()
+	updated, err := strg.Post().UpdatePost(first)
+	require.NoError(t, err)
+	require.Equal(t, slug.Make(first.Title), updated.Slug)
+
+	// The old slug redirects and isn't given to other posts.
+	found, err := strg.Post().GetBySlug(oldSlug)
+	require.NoError(t, err)
+	require.Equal(t, first.ID, found.ID)
+	require.Equal(t, updated.Slug, found.Slug)
+
+	third := createPostWithTitle(t, title)
+	require.NotEqual(t, oldSlug, third.Slug)
+
+	// Restoring the first title brings the old slug back.
+	restored, err := strg.Post().RestoreRevision(first.ID, first.UserID, 1)
+	require.NoError(t, err)
+	require.Equal(t, oldSlug, restored.Slug)
+
+	found, err = strg.Post().GetBySlug(updated.Slug)
+	require.NoError(t, err)
+	require.Equal(t, first.ID, found.ID)
+	require.Equal(t, oldSlug, found.Slug)
+
+	_, err = strg.Post().GetBySlug("missing-" + oldSlug)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestGetPostByPublicID(t *testing.T) {
+	p := createPost(t)
+
+	found, err := strg.Post().GetByPublicID(p.PublicID)
+	require.NoError(t, err)
+	require.Equal(t, p.ID, found.ID)
+
+	_, err = strg.Post().GetByPublicID("not-a-uuid")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	require.NoError(t, strg.Post().DeletePost(p.ID, p.UserID))
+	_, err = strg.Post().GetByPublicID(p.PublicID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = strg.Post().GetBySlug(p.Slug)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,388 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bxcodec/faker/v4"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func createPost(t *testing.T) *repo.Post {
+	c := createCategory(t)
+
+	post, err := strg.Post().Create(&repo.Post{
+		Title:       faker.Sentence(),
+		Description: faker.Paragraph(),
+		ImageUrl:    faker.URL(),
+		UserID:      1,
+		CategoryID:  c.ID,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, post)
+
+	return post
+}
+
+func containsPost(posts []*repo.Post, id int64) bool {
+	for _, p := range posts {
+		if p.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCreatePost(t *testing.T) {
+	p := createPost(t)
+	require.Equal(t, repo.PostStatusDraft, p.Status)
+	require.True(t, p.PublishedAt.IsZero())
+}
+
+func TestPublishPost(t *testing.T) {
+	p := createPost(t)
+
+	post, err := strg.Post().UpdateStatus(p.ID, p.UserID, repo.PostStatusPublished)
+	require.NoError(t, err)
+	require.Equal(t, repo.PostStatusPublished, post.Status)
+	require.False(t, post.PublishedAt.IsZero())
+
+	result, err := strg.Post().GetAll(&repo.GetAllPostsParams{
+		Limit:      10,
+		Page:       1,
+		CategoryID: p.CategoryID,
+	})
+	require.NoError(t, err)
+	require.True(t, containsPost(result.Posts, p.ID))
+}
+
+func TestPublishOtherUsersPost(t *testing.T) {
+	p := createPost(t)
+
+	_, err := strg.Post().UpdateStatus(p.ID, p.UserID+1, repo.PostStatusPublished)
+	require.Error(t, err)
+}
+
+func TestUnpublishPost(t *testing.T) {
+	p := createPost(t)
+
+	published, err := strg.Post().UpdateStatus(p.ID, p.UserID, repo.PostStatusPublished)
+	require.NoError(t, err)
+
+	post, err := strg.Post().UpdateStatus(p.ID, p.UserID, repo.PostStatusDraft)
+	require.NoError(t, err)
+	require.Equal(t, repo.PostStatusDraft, post.Status)
+	require.True(t, published.PublishedAt.Equal(post.PublishedAt))
+}
+
+func TestGetAllPostsHidesDrafts(t *testing.T) {
+	p := createPost(t)
+
+	result, err := strg.Post().GetAll(&repo.GetAllPostsParams{
+		Limit:      10,
+		Page:       1,
+		CategoryID: p.CategoryID,
+		UserID:     p.UserID,
+	})
+	require.NoError(t, err)
+	require.False(t, containsPost(result.Posts, p.ID))
+
+	result, err = strg.Post().GetAll(&repo.GetAllPostsParams{
+		Limit:         10,
+		Page:          1,
+		CategoryID:    p.CategoryID,
+		UserID:        p.UserID,
+		IncludeDrafts: true,
+	})
+	require.NoError(t, err)
+	require.True(t, containsPost(result.Posts, p.ID))
+}
+
+func TestGetAllPostsHidesArchived(t *testing.T) {
+	p := createPost(t)
+
+	_, err := strg.Post().UpdateStatus(p.ID, p.UserID, repo.PostStatusArchived)
+	require.NoError(t, err)
+
+	result, err := strg.Post().GetAll(&repo.GetAllPostsParams{
+		Limit:         10,
+		Page:          1,
+		CategoryID:    p.CategoryID,
+		UserID:        p.UserID,
+		IncludeDrafts: true,
+	})
+	require.NoError(t, err)
+	require.False(t, containsPost(result.Posts, p.ID))
+}
+
+func TestSchedulePublish(t *testing.T) {
+	p := createPost(t)
+	publishAt := time.Now().Add(time.Hour)
+
+	post, err := strg.Post().SchedulePublish(p.ID, p.UserID, publishAt)
+	require.NoError(t, err)
+	require.WithinDuration(t, publishAt, post.PublishAt, time.Millisecond)
+
+	_, err = strg.Post().SchedulePublish(p.ID, p.UserID, publishAt)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	publishAt = publishAt.Add(time.Hour)
+	post, err = strg.Post().ReschedulePublish(p.ID, p.UserID, publishAt)
+	require.NoError(t, err)
+	require.WithinDuration(t, publishAt, post.PublishAt, time.Millisecond)
+
+	post, err = strg.Post().CancelScheduledPublish(p.ID, p.UserID)
+	require.NoError(t, err)
+	require.True(t, post.PublishAt.IsZero())
+	require.Equal(t, repo.PostStatusDraft, post.Status)
+}
+
+func TestPublishDue(t *testing.T) {
+	p := createPost(t)
+
+	_, err := strg.Post().SchedulePublish(p.ID, p.UserID, time.Now().Add(time.Second))
+	require.NoError(t, err)
+
+	posts, err := strg.Post().PublishDue(time.Now().Add(time.Minute), 1000)
+	require.NoError(t, err)
+	require.True(t, containsPost(posts, p.ID))
+
+	post, err := strg.Post().Get(p.ID)
+	require.NoError(t, err)
+	require.Equal(t, repo.PostStatusPublished, post.Status)
+	require.True(t, post.PublishAt.IsZero())
+	require.False(t, post.PublishedAt.IsZero())
+}
+
+func TestSearchPosts(t *testing.T) {
+	p := createPost(t)
+	word := strings.ToLower(faker.Word()) + faker.UUIDDigit()
+
+	p.Description = "first line\nsomething about " + word + " here"
+	_, err := strg.Post().UpdatePost(p)
+	require.NoError(t, err)
+
+	_, err = strg.Post().UpdateStatus(p.ID, p.UserID, repo.PostStatusPublished)
+	require.NoError(t, err)
+
+	result, err := strg.Post().Search(&repo.SearchPostsParams{
+		Query: word,
+		Limit: 10,
+		Page:  1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), result.Count)
+	require.Equal(t, p.ID, result.Results[0].Post.ID)
+	require.Contains(t, result.Results[0].DescriptionHighlight, "<mark>"+word+"</mark>")
+
+	result, err = strg.Post().Search(&repo.SearchPostsParams{
+		Query: "' OR 1=1 --",
+		Limit: 10,
+		Page:  1,
+	})
+	require.NoError(t, err)
+	require.False(t, containsPost(postsOf(result), p.ID))
+}
+
+func postsOf(result *repo.SearchPostsResult) []*repo.Post {
+	posts := make([]*repo.Post, 0, len(result.Results))
+	for _, r := range result.Results {
+		posts = append(posts, r.Post)
+	}
+	return posts
+}
+
+func TestGetAllPostsMaliciousParams(t *testing.T) {
+	p := createPost(t)
+
+	result, err := strg.Post().GetAll(&repo.GetAllPostsParams{
+		Limit:         10,
+		Page:          1,
+		Search:        "%' OR 1=1; DROP TABLE posts; --",
+		UserID:        p.UserID,
+		IncludeDrafts: true,
+	})
+	require.NoError(t, err)
+	require.False(t, containsPost(result.Posts, p.ID))
+
+	_, err = strg.Post().GetAll(&repo.GetAllPostsParams{
+		Limit:      10,
+		Page:       1,
+		SortByDate: "desc; DROP TABLE posts; --",
+	})
+	require.ErrorIs(t, err, repo.ErrInvalidArgument)
+
+	_, err = strg.Post().Get(p.ID)
+	require.NoError(t, err)
+}
+
+func TestGetAllPostsKeyset(t *testing.T) {
+	c := createCategory(t)
+
+	ids := make([]int64, 0, 3)
+	for i := 0; i < 3; i++ {
+		p, err := strg.Post().Create(&repo.Post{
+			Title:       faker.Sentence(),
+			Description: faker.Paragraph(),
+			UserID:      1,
+			CategoryID:  c.ID,
+			Status:      repo.PostStatusPublished,
+		})
+		require.NoError(t, err)
+		ids = append(ids, p.ID)
+	}
+
+	params := &repo.GetAllPostsParams{
+		Limit:      2,
+		Page:       1,
+		CategoryID: c.ID,
+	}
+	first, err := strg.Post().GetAll(params)
+	require.NoError(t, err)
+	require.Len(t, first.Posts, 2)
+	require.Equal(t, int32(3), first.Count)
+	require.NotNil(t, first.Next)
+
+	// A post created between page loads doesn't shift the next page.
+	_, err = strg.Post().Create(&repo.Post{
+		Title:      faker.Sentence(),
+		UserID:     1,
+		CategoryID: c.ID,
+		Status:     repo.PostStatusPublished,
+	})
+	require.NoError(t, err)
+
+	params.After = first.Next
+	second, err := strg.Post().GetAll(params)
+	require.NoError(t, err)
+	require.Len(t, second.Posts, 1)
+	require.Equal(t, ids[0], second.Posts[0].ID)
+	require.Nil(t, second.Next)
+}
+
+func TestAddViews(t *testing.T) {
+	p1 := createPost(t)
+	p2 := createPost(t)
+
+	post, err := strg.Post().Get(p1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(0), post.ViewsCount)
+
+	err = strg.Post().AddViews(map[int64]int64{p1.ID: 3, p2.ID: 1})
+	require.NoError(t, err)
+
+	post, err = strg.Post().Get(p1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), post.ViewsCount)
+
+	post, err = strg.Post().Get(p2.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), post.ViewsCount)
+}
+
+func createPublishedPosts(t *testing.T, n int) []*repo.Post {
+	c := createCategory(t)
+
+	posts := make([]*repo.Post, 0, n)
+	for i := 0; i < n; i++ {
+		p, err := strg.Post().Create(&repo.Post{
+			Title:       faker.Sentence(),
+			Description: faker.Paragraph(),
+			UserID:      1,
+			CategoryID:  c.ID,
+			Status:      repo.PostStatusPublished,
+		})
+		require.NoError(t, err)
+		posts = append(posts, p)
+	}
+	return posts
+}
+
+func TestGetAllPostsSorts(t *testing.T) {
+	posts := createPublishedPosts(t, 3)
+
+	// posts[1] has the most likes, posts[0] and posts[2] tie on one.
+	for i, likes := range []int{1, 3, 1} {
+		for u := 0; u < likes; u++ {
+			_, err := strg.Like().SetReaction(posts[i].ID, int64(200+u), true)
+			require.NoError(t, err)
+		}
+	}
+	createComment(t, posts[2].ID, 1)
+
+	params := &repo.GetAllPostsParams{
+		Limit:      2,
+		Page:       1,
+		CategoryID: posts[0].CategoryID,
+		Sort:       repo.PostSortMostLiked,
+	}
+	first, err := strg.Post().GetAll(params)
+	require.NoError(t, err)
+	require.Len(t, first.Posts, 2)
+	require.Equal(t, posts[1].ID, first.Posts[0].ID)
+	require.Equal(t, posts[2].ID, first.Posts[1].ID)
+	require.NotNil(t, first.Next)
+
+	params.After = first.Next
+	second, err := strg.Post().GetAll(params)
+	require.NoError(t, err)
+	require.Len(t, second.Posts, 1)
+	require.Equal(t, posts[0].ID, second.Posts[0].ID)
+
+	commented, err := strg.Post().GetAll(&repo.GetAllPostsParams{
+		CategoryID: posts[0].CategoryID,
+		Sort:       repo.PostSortMostCommented,
+	})
+	require.NoError(t, err)
+	require.Equal(t, posts[2].ID, commented.Posts[0].ID)
+
+	oldest, err := strg.Post().GetAll(&repo.GetAllPostsParams{
+		CategoryID: posts[0].CategoryID,
+		Sort:       repo.PostSortOldest,
+	})
+	require.NoError(t, err)
+	require.Equal(t, posts[0].ID, oldest.Posts[0].ID)
+
+	_, err = strg.Post().GetAll(&repo.GetAllPostsParams{Sort: "random"})
+	require.ErrorIs(t, err, repo.ErrInvalidArgument)
+}
+
+func TestUpdateTrendingScores(t *testing.T) {
+	posts := createPublishedPosts(t, 2)
+
+	_, err := strg.Like().SetReaction(posts[1].ID, 300, true)
+	require.NoError(t, err)
+	createComment(t, posts[1].ID, 300)
+
+	_, err = strg.Post().UpdateTrendingScores(time.Now())
+	require.NoError(t, err)
+
+	res, err := strg.Post().GetAll(&repo.GetAllPostsParams{
+		CategoryID: posts[0].CategoryID,
+		Sort:       repo.PostSortTrending,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Posts, 2)
+	require.Equal(t, posts[1].ID, res.Posts[0].ID)
+	require.Greater(t, res.Posts[0].TrendingScore, 0.0)
+	require.Zero(t, res.Posts[1].TrendingScore)
+
+	// The same points are worth less a day later.
+	_, err = strg.Post().UpdateTrendingScores(time.Now().Add(24 * time.Hour))
+	require.NoError(t, err)
+
+	later, err := strg.Post().Get(posts[1].ID)
+	require.NoError(t, err)
+	require.Less(t, later.TrendingScore, res.Posts[0].TrendingScore)
+
+	// Posts which left the window stop trending.
+	_, err = strg.Post().UpdateTrendingScores(time.Now().Add(8 * 24 * time.Hour))
+	require.NoError(t, err)
+
+	old, err := strg.Post().Get(posts[1].ID)
+	require.NoError(t, err)
+	require.Zero(t, old.TrendingScore)
+}
@@ -0,0 +1,111 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeletePostMovesToTrash(t *testing.T) {
+	const author = 601
+	category := createCategory(t)
+	p := createPublishedPost(t, author, category.ID)
+	createComment(t, p.ID, 602)
+	react(t, p.ID, 602, true)
+
+	require.ErrorIs(t, strg.Post().DeletePost(p.ID, author+1), sql.ErrNoRows)
+	require.NoError(t, strg.Post().DeletePost(p.ID, author))
+	require.ErrorIs(t, strg.Post().DeletePost(p.ID, author), sql.ErrNoRows)
+
+	_, err := strg.Post().Get(p.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	all, err := strg.Post().GetAll(&repo.GetAllPostsParams{Limit: 10, CategoryID: category.ID})
+	require.NoError(t, err)
+	require.False(t, containsPost(all.Posts, p.ID))
+
+	_, err = strg.Like().SetReaction(p.ID, 603, true)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	trash, err := strg.Post().GetTrash(&repo.GetTrashParams{UserID: author, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, trash.Posts, 1)
+	require.Equal(t, p.ID, trash.Posts[0].ID)
+	require.False(t, trash.Posts[0].DeletedAt.IsZero())
+
+	_, err = strg.Post().RestorePost(p.ID, author+1)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	restored, err := strg.Post().RestorePost(p.ID, author)
+	require.NoError(t, err)
+	require.True(t, restored.DeletedAt.IsZero())
+	require.Equal(t, int64(1), restored.CommentsCount)
+	require.Equal(t, int64(1), restored.LikesCount)
+
+	_, err = strg.Post().Get(p.ID)
+	require.NoError(t, err)
+}
+
+func TestGetTrashKeyset(t *testing.T) {
+	const author = 611
+	category := createCategory(t)
+	first := createPublishedPost(t, author, category.ID)
+	second := createPublishedPost(t, author, category.ID)
+	require.NoError(t, strg.Post().DeletePost(first.ID, author))
+	require.NoError(t, strg.Post().DeletePost(second.ID, author))
+
+	params := &repo.GetTrashParams{UserID: author, Limit: 1}
+	page, err := strg.Post().GetTrash(params)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), page.Count)
+	require.Len(t, page.Posts, 1)
+	require.Equal(t, second.ID, page.Posts[0].ID)
+	require.NotNil(t, page.Next)
+
+	params.After = page.Next
+	page, err = strg.Post().GetTrash(params)
+	require.NoError(t, err)
+	require.Len(t, page.Posts, 1)
+	require.Equal(t, first.ID, page.Posts[0].ID)
+	require.Nil(t, page.Next)
+}
+
+func TestPurgeDeleted(t *testing.T) {
+	const author = 621
+	category := createCategory(t)
+	p := createPublishedPost(t, author, category.ID)
+	kept := createPublishedPost(t, author, category.ID)
+	comment := createComment(t, p.ID, 622)
+	createReply(t, comment, 623)
+	react(t, p.ID, 622, false)
+	_, err := strg.Bookmark().Add(622, p.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, strg.Post().DeletePost(p.ID, author))
+
+	ids, err := strg.Post().PurgeDeleted(time.Now().Add(-time.Hour), 100)
+	require.NoError(t, err)
+	require.NotContains(t, ids, p.ID)
+
+	ids, err = strg.Post().PurgeDeleted(time.Now().Add(time.Minute), 100)
+	require.NoError(t, err)
+	require.Contains(t, ids, p.ID)
+	require.NotContains(t, ids, kept.ID)
+
+	var left int
+	err = db.QueryRow(`
+		SELECT
+			(SELECT count(1) FROM posts WHERE id=$1) +
+			(SELECT count(1) FROM comments WHERE post_id=$1) +
+			(SELECT count(1) FROM likes WHERE post_id=$1) +
+			(SELECT count(1) FROM bookmarks WHERE post_id=$1)
+	`, p.ID).Scan(&left)
+	require.NoError(t, err)
+	require.Zero(t, left)
+
+	_, err = strg.Post().Get(kept.ID)
+	require.NoError(t, err)
+}
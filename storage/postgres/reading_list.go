@@ -0,0 +1,328 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+)
+
+type readingListRepo struct {
+	db *sqlx.DB
+}
+
+func NewReadingList(db *sqlx.DB) repo.ReadingListStorageI {
+	return &readingListRepo{
+		db: db,
+	}
+}
+
+const readingListColumns = `
+	id,
+	user_id,
+	name,
+	is_public,
+	(
+		SELECT count(1) FROM reading_list_posts rp
+		INNER JOIN posts p ON p.id=rp.post_id
+		WHERE rp.list_id=reading_lists.id AND p.status='` + repo.PostStatusPublished + `' AND p.deleted_at IS NULL
+	) AS posts_count,
+	created_at,
+	updated_at`
+
+func scanReadingList(row scanner) (*repo.ReadingList, error) {
+	var (
+		res       repo.ReadingList
+		updatedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&res.ID,
+		&res.UserID,
+		&res.Name,
+		&res.IsPublic,
+		&res.PostsCount,
+		&res.CreatedAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res.UpdatedAt = updatedAt.Time
+	return &res, nil
+}
+
+func (rr *readingListRepo) Create(l *repo.ReadingList) (*repo.ReadingList, error) {
+	query := `
+		INSERT INTO reading_lists(user_id, name, is_public)
+		VALUES($1, $2, $3)
+		RETURNING ` + readingListColumns
+
+	res, err := scanReadingList(rr.db.QueryRow(query, l.UserID, l.Name, l.IsPublic))
+	if isUniqueViolation(err) {
+		return nil, fmt.Errorf("%w: reading list %q", repo.ErrAlreadyExists, l.Name)
+	}
+	return res, err
+}
+
+func (rr *readingListRepo) Get(id int64) (*repo.ReadingList, error) {
+	query := `
+		SELECT ` + readingListColumns + `
+		FROM reading_lists WHERE id=$1
+	`
+
+	return scanReadingList(rr.db.QueryRow(query, id))
+}
+
+func (rr *readingListRepo) GetAll(userID int64, includePrivate bool) ([]*repo.ReadingList, error) {
+	query := `
+		SELECT ` + readingListColumns + `
+		FROM reading_lists
+		WHERE user_id=$1 AND (is_public OR $2)
+		ORDER BY created_at, id
+	`
+
+	rows, err := rr.db.Query(query, userID, includePrivate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*repo.ReadingList, 0)
+	for rows.Next() {
+		l, err := scanReadingList(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, l)
+	}
+
+	return result, rows.Err()
+}
+
+func (rr *readingListRepo) Update(l *repo.ReadingList) (*repo.ReadingList, error) {
+	tx, err := rr.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockReadingList(tx, l.ID, l.UserID); err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE reading_lists SET name=$1, is_public=$2, updated_at=$3
+		WHERE id=$4
+		RETURNING ` + readingListColumns
+
+	res, err := scanReadingList(tx.QueryRow(query, l.Name, l.IsPublic, time.Now(), l.ID))
+	if isUniqueViolation(err) {
+		return nil, fmt.Errorf("%w: reading list %q", repo.ErrAlreadyExists, l.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (rr *readingListRepo) Delete(id, userID int64) error {
+	tx, err := rr.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockReadingList(tx, id, userID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM reading_lists WHERE id=$1", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (rr *readingListRepo) AddPost(id, userID, postID int64) error {
+	tx, err := rr.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The lock also keeps concurrent appends from taking one position.
+	if err := lockReadingList(tx, id, userID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO reading_list_posts(list_id, post_id, position)
+		SELECT $1, p.id, COALESCE(
+			(SELECT max(position) FROM reading_list_posts WHERE list_id=$1), 0
+		) + 1
+		FROM posts p WHERE p.id=$2 AND p.status=$3 AND p.deleted_at IS NULL
+		ON CONFLICT DO NOTHING
+		RETURNING post_id
+	`
+
+	var added int64
+	err = tx.QueryRow(query, id, postID, repo.PostStatusPublished).Scan(&added)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Either the post is already in the list or there is no such post.
+		var exists bool
+		err = tx.QueryRow(
+			"SELECT EXISTS (SELECT 1 FROM reading_list_posts WHERE list_id=$1 AND post_id=$2)",
+			id, postID,
+		).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return sql.ErrNoRows
+		}
+	} else if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (rr *readingListRepo) RemovePost(id, userID, postID int64) error {
+	tx, err := rr.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockReadingList(tx, id, userID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM reading_list_posts WHERE list_id=$1 AND post_id=$2", id, postID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (rr *readingListRepo) Reorder(id, userID int64, postIDs []int64) error {
+	seen := make(map[int64]bool, len(postIDs))
+	for _, postID := range postIDs {
+		if seen[postID] {
+			return fmt.Errorf("%w: post %d is listed twice", repo.ErrInvalidArgument, postID)
+		}
+		seen[postID] = true
+	}
+
+	tx, err := rr.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockReadingList(tx, id, userID); err != nil {
+		return err
+	}
+
+	// Only the posts shown by GetPosts are ordered by the client, the
+	// hidden ones keep their order after them.
+	var count int
+	query := `
+		SELECT count(1) FROM reading_list_posts rp
+		INNER JOIN posts p ON p.id=rp.post_id
+		WHERE rp.list_id=$1 AND p.status=$2 AND p.deleted_at IS NULL
+	`
+	err = tx.QueryRow(query, id, repo.PostStatusPublished).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE reading_list_posts rp SET position=o.position
+		FROM unnest($2::INTEGER[]) WITH ORDINALITY AS o(post_id, position), posts p
+		WHERE rp.list_id=$1 AND rp.post_id=o.post_id
+			AND p.id=rp.post_id AND p.status=$3 AND p.deleted_at IS NULL
+	`
+	res, err := tx.Exec(query, id, pq.Array(postIDs), repo.PostStatusPublished)
+	if err != nil {
+		return err
+	}
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if int(updated) != len(postIDs) || len(postIDs) != count {
+		return fmt.Errorf("%w: the order must hold every post of the list", repo.ErrInvalidArgument)
+	}
+
+	query = `
+		UPDATE reading_list_posts rp SET position=$2 + h.n
+		FROM (
+			SELECT rp.post_id, row_number() OVER (ORDER BY rp.position) AS n
+			FROM reading_list_posts rp
+			INNER JOIN posts p ON p.id=rp.post_id
+			WHERE rp.list_id=$1 AND (p.status<>$3 OR p.deleted_at IS NOT NULL)
+		) h
+		WHERE rp.list_id=$1 AND rp.post_id=h.post_id
+	`
+	_, err = tx.Exec(query, id, len(postIDs), repo.PostStatusPublished)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (rr *readingListRepo) GetPosts(id int64) ([]*repo.Post, error) {
+	query := `
+		SELECT
+			` + prefixColumns("posts", postColumns) + `
+		FROM reading_list_posts rp
+		INNER JOIN posts ON posts.id=rp.post_id
+		WHERE rp.list_id=$1 AND posts.status=$2 AND posts.deleted_at IS NULL
+		ORDER BY rp.position
+	`
+
+	rows, err := rr.db.Query(query, id, repo.PostStatusPublished)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*repo.Post, 0)
+	for rows.Next() {
+		p, err := scanPost(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+
+	return result, rows.Err()
+}
+
+// lockReadingList locks the list of the user until the transaction ends.
+func lockReadingList(tx *sqlx.Tx, id, userID int64) error {
+	var owner int64
+	err := tx.QueryRow("SELECT user_id FROM reading_lists WHERE id=$1 FOR UPDATE", id).Scan(&owner)
+	if err != nil {
+		return err
+	}
+	if owner != userID {
+		return repo.ErrPermissionDenied
+	}
+	return nil
+}
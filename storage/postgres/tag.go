@@ -0,0 +1,214 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mirasildev/medium_post_service/storage/postgres/internal/qb"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+)
+
+type tagRepo struct {
+	db *sqlx.DB
+}
+
+func NewTag(db *sqlx.DB) repo.TagStorageI {
+	return &tagRepo{
+		db: db,
+	}
+}
+
+func (tr *tagRepo) SetPostTags(postID, userID int64, names []string) ([]*repo.Tag, error) {
+	tx, err := tr.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRow("SELECT id FROM posts WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL FOR UPDATE", postID, userID).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO tags(name) SELECT unnest($1::VARCHAR[])
+		ON CONFLICT (name) DO NOTHING
+	`, pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM post_tags pt USING tags t
+		WHERE pt.tag_id=t.id AND pt.post_id=$1 AND NOT t.name=ANY($2::VARCHAR[])
+	`, postID, pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO post_tags(post_id, tag_id)
+		SELECT $1, id FROM tags WHERE name=ANY($2::VARCHAR[])
+		ON CONFLICT DO NOTHING
+	`, postID, pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := getPostTags(tx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// tagPostsCountColumn counts the posts of the tag which everyone can see.
+const tagPostsCountColumn = `(
+				SELECT count(1) FROM post_tags pc
+				INNER JOIN posts p ON p.id=pc.post_id
+				WHERE pc.tag_id=t.id AND p.status='` + repo.PostStatusPublished + `' AND p.deleted_at IS NULL
+			)`
+
+func (tr *tagRepo) GetPostTags(postID int64) ([]*repo.Tag, error) {
+	return getPostTags(tr.db, postID)
+}
+
+func getPostTags(q sqlx.Queryer, postID int64) ([]*repo.Tag, error) {
+	query := `
+		SELECT
+			t.id,
+			t.name,
+			t.created_at,
+			` + tagPostsCountColumn + `
+		FROM tags t
+		INNER JOIN post_tags pt ON pt.tag_id=t.id
+		WHERE pt.post_id=$1
+		ORDER BY t.name
+	`
+
+	rows, err := q.Query(query, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*repo.Tag, 0)
+	for rows.Next() {
+		var t repo.Tag
+		err := rows.Scan(
+			&t.ID,
+			&t.Name,
+			&t.CreatedAt,
+			&t.PostsCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &t)
+	}
+
+	return result, rows.Err()
+}
+
+var tagSortColumns = map[string]string{
+	"name": "t.name",
+}
+
+func (tr *tagRepo) GetAll(params *repo.GetAllTagsParams) (*repo.GetAllTagsResult, error) {
+	result := repo.GetAllTagsResult{
+		Tags: make([]*repo.Tag, 0),
+	}
+
+	b := qb.New()
+	if params.Search != "" {
+		b.Where("t.name ilike '%' || ? || '%'", params.Search)
+	}
+
+	if err := b.OrderBy(tagSortColumns, "name", "asc"); err != nil {
+		return nil, err
+	}
+	b.Page(params.Limit, params.Page)
+
+	query, args := b.Build(`
+		SELECT
+			t.id,
+			t.name,
+			t.created_at,
+			` + tagPostsCountColumn + `
+		FROM tags t`)
+
+	rows, err := tr.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t repo.Tag
+		err := rows.Scan(
+			&t.ID,
+			&t.Name,
+			&t.CreatedAt,
+			&t.PostsCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result.Tags = append(result.Tags, &t)
+	}
+
+	queryCount, args := b.BuildCount(`SELECT count(1) FROM tags t`)
+	err = tr.db.QueryRow(queryCount, args...).Scan(&result.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (tr *tagRepo) GetPopular(limit int32, since time.Time) ([]*repo.Tag, error) {
+	query := `
+		SELECT
+			t.id,
+			t.name,
+			t.created_at,
+			count(1) AS posts_count
+		FROM tags t
+		INNER JOIN post_tags pt ON pt.tag_id=t.id
+		INNER JOIN posts p ON p.id=pt.post_id
+		WHERE p.status=$1 AND p.deleted_at IS NULL AND p.published_at >= $2
+		GROUP BY t.id
+		ORDER BY posts_count DESC, t.name
+		LIMIT $3
+	`
+
+	rows, err := tr.db.Query(query, repo.PostStatusPublished, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*repo.Tag, 0)
+	for rows.Next() {
+		var t repo.Tag
+		err := rows.Scan(
+			&t.ID,
+			&t.Name,
+			&t.CreatedAt,
+			&t.PostsCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &t)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,96 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bxcodec/faker/v4"
+	"github.com/mirasildev/medium_post_service/storage/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPostTags(t *testing.T) {
+	p := createPost(t)
+	first, second := faker.Word()+"-1", faker.Word()+"-2"
+
+	tags, err := strg.Tag().SetPostTags(p.ID, p.UserID, []string{first, second})
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+
+	tags, err = strg.Tag().SetPostTags(p.ID, p.UserID, []string{second})
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	require.Equal(t, second, tags[0].Name)
+
+	_, err = strg.Tag().SetPostTags(p.ID, p.UserID+1, []string{first})
+	require.Error(t, err)
+}
+
+func TestGetAllPostsByTags(t *testing.T) {
+	p := createPost(t)
+	tag := faker.Word() + "-" + faker.Word()
+
+	_, err := strg.Tag().SetPostTags(p.ID, p.UserID, []string{tag})
+	require.NoError(t, err)
+
+	_, err = strg.Post().UpdateStatus(p.ID, p.UserID, repo.PostStatusPublished)
+	require.NoError(t, err)
+
+	result, err := strg.Post().GetAll(&repo.GetAllPostsParams{
+		Limit: 10,
+		Page:  1,
+		Tags:  []string{tag, "no-such-tag"},
+	})
+	require.NoError(t, err)
+	require.True(t, containsPost(result.Posts, p.ID))
+
+	popular, err := strg.Tag().GetPopular(1000, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.NotEmpty(t, popular)
+}
+
+func TestTagPostsCountSkipsHiddenPosts(t *testing.T) {
+	draft := createPost(t)
+	published := createPost(t)
+	tag := faker.Word() + "-" + faker.Word()
+
+	for _, p := range []*repo.Post{draft, published} {
+		_, err := strg.Tag().SetPostTags(p.ID, p.UserID, []string{tag})
+		require.NoError(t, err)
+	}
+	_, err := strg.Post().UpdateStatus(published.ID, published.UserID, repo.PostStatusPublished)
+	require.NoError(t, err)
+
+	tags, err := strg.Tag().GetPostTags(draft.ID)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	require.Equal(t, int32(1), tags[0].PostsCount)
+
+	result, err := strg.Tag().GetAll(&repo.GetAllTagsParams{Limit: 10, Page: 1, Search: tag})
+	require.NoError(t, err)
+	require.Len(t, result.Tags, 1)
+	require.Equal(t, int32(1), result.Tags[0].PostsCount)
+}
+
+func TestGetPopularTagsByPublishTime(t *testing.T) {
+	p := createPost(t)
+	tag := faker.Word() + "-" + faker.Word()
+
+	_, err := strg.Tag().SetPostTags(p.ID, p.UserID, []string{tag})
+	require.NoError(t, err)
+
+	// A draft written long ago counts from when it is published.
+	_, err = db.Exec("UPDATE posts SET created_at=$1 WHERE id=$2", time.Now().AddDate(0, -3, 0), p.ID)
+	require.NoError(t, err)
+	_, err = strg.Post().UpdateStatus(p.ID, p.UserID, repo.PostStatusPublished)
+	require.NoError(t, err)
+
+	popular, err := strg.Tag().GetPopular(1000, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	found := false
+	for _, popularTag := range popular {
+		found = found || popularTag.Name == tag
+	}
+	require.True(t, found)
+}
@@ -0,0 +1,38 @@
+package repo
+
+import "time"
+
+type Bookmark struct {
+	ID        int64
+	UserID    int64
+	PostID    int64
+	CreatedAt time.Time
+	Post      *Post
+}
+
+type GetBookmarksParams struct {
+	UserID int64
+	Limit  int32
+	// After continues the list from the keyset of a previous page.
+	After *Keyset
+}
+
+type GetBookmarksResult struct {
+	Bookmarks []*Bookmark
+	Count     int32
+	// Next is the keyset of the last bookmark, it is nil on the last page.
+	Next *Keyset
+}
+
+type BookmarkStorageI interface {
+	// Add bookmarks a published post, adding it again keeps the first
+	// bookmark. It fails with sql.ErrNoRows when there is no such post.
+	Add(userID, postID int64) (*Bookmark, error)
+	// Remove is a no-op when the post isn't bookmarked.
+	Remove(userID, postID int64) error
+	// GetAll lists the bookmarked posts which are still published, the
+	// newest bookmark first.
+	GetAll(params *GetBookmarksParams) (*GetBookmarksResult, error)
+	// GetBookmarked returns which of the posts the user has bookmarked.
+	GetBookmarked(userID int64, postIDs []int64) (map[int64]bool, error)
+}
@@ -2,16 +2,54 @@ package repo
 
 import "time"
 
+// MaxCategoryDepth is the deepest level a category can be at, root
+// categories are at depth 0.
+const MaxCategoryDepth = 2
+
+// The orders of GetAll, every one breaks ties by id.
+const (
+	CategorySortNewest = "newest"
+	// CategorySortPopular orders by the number of followers.
+	CategorySortPopular        = "popular"
+	CategorySortMostPosts      = "most_posts"
+	CategorySortRecentlyActive = "recently_active"
+	// CategorySortGrowing orders by the followers gained in the last week.
+	CategorySortGrowing = "growing"
+)
+
 type Category struct {
-	ID        int64
-	Title     string
+	ID    int64
+	Title string
+	// Slug is made unique from the title when the category is created and
+	// is kept when the title changes, so links to it stay valid.
+	Slug string
+	// ParentID is 0 for root categories.
+	ParentID  int64
 	CreatedAt time.Time
+	// Stats is filled only by GetAll.
+	Stats *CategoryStats
+}
+
+// CategoryStats are computed when RefreshStats runs, they are zero for
+// the categories created since then. Only the published posts of the
+// category itself are counted, not the ones of its descendants.
+type CategoryStats struct {
+	PostsCount     int64
+	LastPostAt     time.Time
+	FollowersCount int64
+	// WeeklyPosts and WeeklyFollowers are the posts published and the
+	// followers gained in the 7 days before the refresh.
+	WeeklyPosts     int64
+	WeeklyFollowers int64
 }
 
 type GetAllCategoriesParams struct {
 	Limit  int32
 	Page   int32
 	Search string
+	// Sort is one of the CategorySort constants, the newest first when
+	// it is empty.
+	Sort string
 }
 
 type GetAllCategoriesResult struct {
@@ -20,9 +58,29 @@ type GetAllCategoriesResult struct {
 }
 
 type CategoryStorageI interface {
+	// Create stores a root category or, when c.ParentID is set, a child
+	// which is at most MaxCategoryDepth deep. A taken title fails with
+	// ErrAlreadyExists.
 	Create(c *Category) (*Category, error)
 	Get(id int64) (*Category, error)
+	GetBySlug(slug string) (*Category, error)
 	GetAll(params *GetAllCategoriesParams) (*GetAllCategoriesResult, error)
+	// GetTree returns the category and its descendants, or every category
+	// when rootID is 0. Parents come before their children.
+	GetTree(rootID int64) ([]*Category, error)
 	Update(c *Category) (*Category, error)
-	Delete(id int64) error
+	// Move puts the category with its subtree under parentID, or makes it
+	// a root when parentID is 0.
+	Move(id, parentID int64) (*Category, error)
+	// Delete moves the posts, subscribers and children of the category to
+	// targetID and deletes it. A category which has posts or children
+	// can't be deleted without a target, it fails with
+	// ErrFailedPrecondition.
+	Delete(id, targetID int64) error
+	// Merge moves the posts, subscribers and children of the source
+	// categories to the target one and deletes them in one transaction.
+	Merge(targetID int64, sourceIDs []int64) (*Category, error)
+	// RefreshStats recomputes the stats of every category without
+	// blocking the readers.
+	RefreshStats() error
 }
@@ -0,0 +1,9 @@
+package repo
+
+type CategorySubscriptionStorageI interface {
+	// Subscribe is a no-op for a subscribed user and fails with
+	// sql.ErrNoRows when the category doesn't exist.
+	Subscribe(userID, categoryID int64) error
+	// Unsubscribe is a no-op when the user isn't subscribed.
+	Unsubscribe(userID, categoryID int64) error
+}
@@ -2,14 +2,24 @@ package repo
 
 import "time"
 
+// MaxCommentDepth is the deepest level a reply can be at, root comments
+// are at depth 0.
+const MaxCommentDepth = 3
+
 type Comment struct {
-	ID          int64
-	UserID      int64
-	PostID      int64
-	Description string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	User        struct {
+	ID           int64
+	UserID       int64
+	PostID       int64
+	ParentID     int64
+	Depth        int32
+	RepliesCount int32
+	Description  string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// DeletedAt is set on tombstones, the comments which were deleted
+	// while they had replies. Their description is cleared.
+	DeletedAt time.Time
+	User      struct {
 		FirstName       string
 		LastName        string
 		Email           string
@@ -22,16 +32,31 @@ type GetAllCommentsParams struct {
 	Page   int32
 	UserID int64
 	PostID int64
+	// ParentID lists the direct replies of a comment, oldest first.
+	ParentID int64
+	// TopLevel keeps only the root comments.
+	TopLevel bool
+	// After continues the list from the keyset of a previous page,
+	// Page is ignored when it is set.
+	After *Keyset
 }
 type GetAllCommentsResult struct {
 	Comments []*Comment
 	Count    int32
+	// Next is the keyset of the last comment, it is nil on the last page.
+	Next *Keyset
 }
 
 type CommentStorageI interface {
+	// Create stores a root comment or, when c.ParentID is set, a reply
+	// which is at most MaxCommentDepth deep.
 	Create(c *Comment) (*Comment, error)
 	Get(id int64) (*Comment, error)
 	GetAll(params *GetAllCommentsParams) (*GetAllCommentsResult, error)
+	// Update changes the comment only when c.UserID wrote it.
 	Update(c *Comment) (*Comment, error)
-	Delete(id int64) error
+	// Delete removes the comment when userID wrote it or owns its post.
+	// A comment with replies is turned into a tombstone instead, which is
+	// removed together with its last reply.
+	Delete(id, userID int64) error
 }
\ No newline at end of file
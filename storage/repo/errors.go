@@ -0,0 +1,18 @@
+package repo
+
+import "errors"
+
+var (
+	// ErrInvalidArgument is wrapped by the errors caused by bad list
+	// parameters, e.g. an unknown sort order.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrPermissionDenied is returned when the row exists but the user
+	// is not allowed to change it.
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrAlreadyExists is returned when a unique name is already taken.
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrFailedPrecondition is wrapped by the errors of writes which the
+	// current state of the rows doesn't allow, e.g. deleting a category
+	// which still has posts.
+	ErrFailedPrecondition = errors.New("failed precondition")
+)
@@ -0,0 +1,20 @@
+package repo
+
+type GetFeedParams struct {
+	UserID int64
+	Limit  int32
+	// IncludeSeen keeps the posts the user has already seen.
+	IncludeSeen bool
+	// After continues the feed from the keyset of a previous page.
+	After *Keyset
+}
+
+type FeedStorageI interface {
+	// GetFeed lists the published posts of the authors the user follows
+	// and of the categories they subscribe to, newest first. The user's
+	// own posts are left out.
+	GetFeed(params *GetFeedParams) (*GetAllPostsResult, error)
+	// MarkSeen keeps the posts out of the next feeds of the user, unknown
+	// posts are ignored.
+	MarkSeen(userID int64, postIDs []int64) error
+}
@@ -0,0 +1,8 @@
+package repo
+
+type FollowStorageI interface {
+	// Follow is a no-op when followerID already follows followeeID.
+	Follow(followerID, followeeID int64) error
+	// Unfollow is a no-op when followerID doesn't follow followeeID.
+	Unfollow(followerID, followeeID int64) error
+}
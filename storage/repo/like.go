@@ -1,10 +1,19 @@
 package repo
 
+import "time"
+
+// The reactions of a user to a post, an empty reaction means none.
+const (
+	ReactionLike    = "like"
+	ReactionDislike = "dislike"
+)
+
 type Like struct {
-	ID     int64
-	PostID int64
-	UserID int64
-	Status bool
+	ID        int64
+	PostID    int64
+	UserID    int64
+	Status    bool
+	CreatedAt time.Time
 }
 
 type LikesDislikesCountsResult struct {
@@ -12,8 +21,62 @@ type LikesDislikesCountsResult struct {
 	DislikesCount int64
 }
 
+type GetLikersParams struct {
+	PostID int64
+	// Reaction keeps only the likes or the dislikes, all when empty.
+	Reaction string
+	Limit    int32
+	Page     int32
+	// After continues the list from the keyset of a previous page,
+	// Page is ignored when it is set.
+	After *Keyset
+}
+
+type GetLikersResult struct {
+	Likes []*Like
+	Count int32
+	// Next is the keyset of the last like, it is nil on the last page.
+	Next *Keyset
+}
+
+type GetLikedPostsParams struct {
+	UserID int64
+	// Reaction keeps only the liked or the disliked posts, all when empty.
+	Reaction string
+	// ViewerID fills ViewerReaction of the posts.
+	ViewerID int64
+	Limit    int32
+	Page     int32
+	// After continues the list from the keyset of a previous page,
+	// Page is ignored when it is set.
+	After *Keyset
+}
+
 type LikeStorageI interface {
+	// CreateOrUpdate toggles the reaction of l.UserID: it is stored when
+	// there is none or the other one, and removed when l.Status repeats the
+	// stored one, in which case the returned like is nil.
 	CreateOrUpdate(l *Like) (*Like, error)
+	// SetReaction stores the reaction of the user whatever it was before
+	// and returns the stored like. It fails with sql.ErrNoRows when the
+	// post doesn't exist.
+	SetReaction(postID, userID int64, status bool) (*Like, error)
+	// RemoveReaction takes back the reaction of the user, if any.
+	RemoveReaction(postID, userID int64) error
 	Get(userID, postID int64) (*Like, error)
 	GetLikesDislikesCount(postID int64) (*LikesDislikesCountsResult, error)
+	// GetLikers lists the reactions to a post, newest first.
+	GetLikers(params *GetLikersParams) (*GetLikersResult, error)
+	// GetLikedPosts lists the published posts a user reacted to, the most
+	// recent reaction first. Next of the result is the keyset of the
+	// reaction, not of the post.
+	GetLikedPosts(params *GetLikedPostsParams) (*GetAllPostsResult, error)
+}
+
+// LikeReaction returns the reaction stored as the status of a like.
+func LikeReaction(status bool) string {
+	if status {
+		return ReactionLike
+	}
+	return ReactionDislike
 }
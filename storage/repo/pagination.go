@@ -0,0 +1,13 @@
+package repo
+
+import "time"
+
+// Keyset points at the last row of a page in a list ordered by
+// (created_at, id). The next page starts right after it.
+type Keyset struct {
+	CreatedAt time.Time
+	ID        int64
+	// Value is the sort key of the row in lists ordered by (value, id),
+	// e.g. the views of a post.
+	Value float64
+}
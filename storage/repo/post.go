@@ -2,6 +2,24 @@ package repo
 
 import "time"
 
+const (
+	PostStatusDraft     = "draft"
+	PostStatusPublished = "published"
+	PostStatusArchived  = "archived"
+)
+
+// The orders of GetAll, every one breaks ties by id.
+const (
+	PostSortNewest        = "newest"
+	PostSortOldest        = "oldest"
+	PostSortMostViewed    = "most_viewed"
+	PostSortMostLiked     = "most_liked"
+	PostSortMostCommented = "most_commented"
+	// PostSortTrending orders by TrendingScore, which is refreshed
+	// periodically by UpdateTrendingScores.
+	PostSortTrending = "trending"
+)
+
 type Post struct {
 	ID          int64
 	Title       string
@@ -12,6 +30,27 @@ type Post struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	ViewsCount  int32
+	Status      string
+	PublishedAt time.Time
+	PublishAt   time.Time
+	// The counters are kept in sync by the like and comment repos,
+	// tombstones of comments aren't counted.
+	LikesCount    int64
+	DislikesCount int64
+	CommentsCount int64
+	// TrendingScore decays with the age of the post, it is 0 for the
+	// posts which aren't published or are too old to trend.
+	TrendingScore float64
+	// ViewerReaction is filled only by the lists of posts.
+	ViewerReaction string
+	// DeletedAt is set while the post is in the trash of its author.
+	DeletedAt time.Time
+	// PublicID is a random identifier, unlike ID it doesn't reveal how
+	// many posts there are.
+	PublicID string
+	// Slug is made from the title and changes with it, the previous slugs
+	// keep leading to the post.
+	Slug string
 }
 
 type GetAllPostsParams struct {
@@ -20,18 +59,100 @@ type GetAllPostsParams struct {
 	Search     string
 	CategoryID int64
 	UserID     int64
+	// Sort is one of the PostSort constants, SortByDate picks between
+	// the newest and the oldest first when it is empty.
+	Sort       string
 	SortByDate string
+	// IncludeDrafts also returns the drafts of UserID, it is ignored
+	// when UserID is not set.
+	IncludeDrafts bool
+	// IncludeSubcategories also returns the posts of the descendants of
+	// CategoryID.
+	IncludeSubcategories bool
+	// Tags keeps the posts which have at least one of the tags.
+	Tags []string
+	// After continues the list from the keyset of a previous page,
+	// Page is ignored when it is set.
+	After *Keyset
+	// ViewerID fills ViewerReaction of the posts.
+	ViewerID int64
 }
 
 type GetAllPostsResult struct {
 	Posts []*Post
 	Count int32
+	// Next is the keyset of the last post, it is nil on the last page.
+	Next *Keyset
+}
+
+type GetTrashParams struct {
+	UserID int64
+	Limit  int32
+	// After continues the list from the keyset of a previous page, the
+	// trash is ordered by the time the posts were deleted.
+	After *Keyset
+}
+
+type SearchPostsParams struct {
+	Query      string
+	Limit      int32
+	Page       int32
+	CategoryID int64
+}
+
+type SearchPostResult struct {
+	Post                 *Post
+	Rank                 float32
+	TitleHighlight       string
+	DescriptionHighlight string
+}
+
+type SearchPostsResult struct {
+	Results []*SearchPostResult
+	Count   int32
 }
 
 type PostStorageI interface {
 	Create(p *Post) (*Post, error)
 	Get(id int64) (*Post, error)
+	// GetBySlug returns the post with the slug, the previous slugs of the
+	// post are matched too. The Slug of the result is the current one.
+	GetBySlug(slug string) (*Post, error)
+	GetByPublicID(publicID string) (*Post, error)
+	// AddViews adds the counts to the views of the posts in one query.
+	AddViews(views map[int64]int64) error
 	GetAll(params *GetAllPostsParams) (*GetAllPostsResult, error)
+	// Search ranks the published posts matching a web search like query by
+	// their title and description and highlights the matched words.
+	Search(params *SearchPostsParams) (*SearchPostsResult, error)
+	// UpdatePost stores the new title and description as the next revision.
 	UpdatePost(p *Post) (*Post, error)
+	// DeletePost moves the post to the trash of its author, the trashed
+	// posts are hidden everywhere until they are restored or purged.
 	DeletePost(id int64, UserID int64) error
+	RestorePost(id, userID int64) (*Post, error)
+	// GetTrash lists the trashed posts of the user, the latest deleted
+	// first.
+	GetTrash(params *GetTrashParams) (*GetAllPostsResult, error)
+	// PurgeDeleted permanently deletes at most limit posts trashed before
+	// the given time together with their comments and likes, and returns
+	// their ids. Rows locked by another replica are skipped.
+	PurgeDeleted(before time.Time, limit int) ([]int64, error)
+	UpdateStatus(id, userID int64, status string) (*Post, error)
+	SchedulePublish(id, userID int64, publishAt time.Time) (*Post, error)
+	ReschedulePublish(id, userID int64, publishAt time.Time) (*Post, error)
+	CancelScheduledPublish(id, userID int64) (*Post, error)
+	// PublishDue publishes at most limit drafts whose publish_at is before now.
+	// Rows locked by another replica are skipped.
+	PublishDue(now time.Time, limit int) ([]*Post, error)
+	// RestoreRevision copies the content of an old revision into the post
+	// and stores it as a new revision.
+	RestoreRevision(postID, userID int64, revision int32) (*Post, error)
+	// RecountCounters recomputes the like, dislike and comment counters of
+	// the post, or of every post when postID is 0, and returns the ids of
+	// the posts whose counters had drifted.
+	RecountCounters(postID int64) ([]int64, error)
+	// UpdateTrendingScores recomputes TrendingScore of the posts as of now
+	// and returns the number of posts it changed.
+	UpdateTrendingScores(now time.Time) (int64, error)
 }
@@ -0,0 +1,30 @@
+package repo
+
+import "time"
+
+type PostRevision struct {
+	ID           int64
+	PostID       int64
+	Revision     int32
+	Title        string
+	Description  string
+	UserID       int64
+	RestoredFrom int32
+	CreatedAt    time.Time
+}
+
+type GetAllPostRevisionsParams struct {
+	PostID int64
+	Limit  int32
+	Page   int32
+}
+
+type GetAllPostRevisionsResult struct {
+	Revisions []*PostRevision
+	Count     int32
+}
+
+type PostRevisionStorageI interface {
+	Get(postID int64, revision int32) (*PostRevision, error)
+	GetAll(params *GetAllPostRevisionsParams) (*GetAllPostRevisionsResult, error)
+}
@@ -0,0 +1,39 @@
+package repo
+
+import "time"
+
+type ReadingList struct {
+	ID         int64
+	UserID     int64
+	Name       string
+	IsPublic   bool
+	PostsCount int32
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// The writes of ReadingListStorageI change only the lists of userID, they
+// fail with sql.ErrNoRows when the list doesn't exist and with
+// ErrPermissionDenied when it belongs to another user.
+type ReadingListStorageI interface {
+	// Create fails with ErrAlreadyExists when the user has a list with
+	// the same name.
+	Create(l *ReadingList) (*ReadingList, error)
+	Get(id int64) (*ReadingList, error)
+	// GetAll returns the lists of the user, only the public ones unless
+	// includePrivate is set.
+	GetAll(userID int64, includePrivate bool) ([]*ReadingList, error)
+	// Update renames the list and changes its visibility.
+	Update(l *ReadingList) (*ReadingList, error)
+	Delete(id, userID int64) error
+	// AddPost appends a published post to the end of the list, adding it
+	// again keeps its position.
+	AddPost(id, userID, postID int64) error
+	RemovePost(id, userID, postID int64) error
+	// Reorder puts the posts of the list in the order of postIDs, which
+	// must hold every post returned by GetPosts exactly once. The hidden
+	// posts go after them in their previous order.
+	Reorder(id, userID int64, postIDs []int64) error
+	// GetPosts returns the published posts of the list in its order.
+	GetPosts(id int64) ([]*Post, error)
+}
@@ -0,0 +1,30 @@
+package repo
+
+import "time"
+
+type Tag struct {
+	ID         int64
+	Name       string
+	PostsCount int32
+	CreatedAt  time.Time
+}
+
+type GetAllTagsParams struct {
+	Limit  int32
+	Page   int32
+	Search string
+}
+
+type GetAllTagsResult struct {
+	Tags  []*Tag
+	Count int32
+}
+
+type TagStorageI interface {
+	// SetPostTags replaces the tags of the user's post, unknown tags are created.
+	SetPostTags(postID, userID int64, names []string) ([]*Tag, error)
+	GetPostTags(postID int64) ([]*Tag, error)
+	GetAll(params *GetAllTagsParams) (*GetAllTagsResult, error)
+	// GetPopular returns the tags used by the most posts published after since.
+	GetPopular(limit int32, since time.Time) ([]*Tag, error)
+}
@@ -11,6 +11,13 @@ type StorageI interface {
 	Post() repo.PostStorageI
 	Comment() repo.CommentStorageI
 	Like() repo.LikeStorageI
+	PostRevision() repo.PostRevisionStorageI
+	Tag() repo.TagStorageI
+	Follow() repo.FollowStorageI
+	CategorySubscription() repo.CategorySubscriptionStorageI
+	Feed() repo.FeedStorageI
+	Bookmark() repo.BookmarkStorageI
+	ReadingList() repo.ReadingListStorageI
 }
 
 type storagePg struct {
@@ -18,6 +25,13 @@ type storagePg struct {
 	postRepo     repo.PostStorageI
 	commentRepo  repo.CommentStorageI
 	likeRepo     repo.LikeStorageI
+	revisionRepo repo.PostRevisionStorageI
+	tagRepo      repo.TagStorageI
+	followRepo   repo.FollowStorageI
+	subsRepo     repo.CategorySubscriptionStorageI
+	feedRepo     repo.FeedStorageI
+	bookmarkRepo repo.BookmarkStorageI
+	listRepo     repo.ReadingListStorageI
 }
 
 func NewStoragePg(db *sqlx.DB) StorageI {
@@ -26,6 +40,13 @@ func NewStoragePg(db *sqlx.DB) StorageI {
 		postRepo:     postgres.NewPost(db),
 		commentRepo:  postgres.NewComment(db),
 		likeRepo:     postgres.NewLike(db),
+		revisionRepo: postgres.NewPostRevision(db),
+		tagRepo:      postgres.NewTag(db),
+		followRepo:   postgres.NewFollow(db),
+		subsRepo:     postgres.NewCategorySubscription(db),
+		feedRepo:     postgres.NewFeed(db),
+		bookmarkRepo: postgres.NewBookmark(db),
+		listRepo:     postgres.NewReadingList(db),
 	}
 }
 
@@ -44,3 +65,31 @@ func (s *storagePg) Comment() repo.CommentStorageI {
 func (s *storagePg) Like() repo.LikeStorageI {
 	return s.likeRepo
 }
+
+func (s *storagePg) PostRevision() repo.PostRevisionStorageI {
+	return s.revisionRepo
+}
+
+func (s *storagePg) Tag() repo.TagStorageI {
+	return s.tagRepo
+}
+
+func (s *storagePg) Follow() repo.FollowStorageI {
+	return s.followRepo
+}
+
+func (s *storagePg) CategorySubscription() repo.CategorySubscriptionStorageI {
+	return s.subsRepo
+}
+
+func (s *storagePg) Feed() repo.FeedStorageI {
+	return s.feedRepo
+}
+
+func (s *storagePg) Bookmark() repo.BookmarkStorageI {
+	return s.bookmarkRepo
+}
+
+func (s *storagePg) ReadingList() repo.ReadingListStorageI {
+	return s.listRepo
+}
@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v9"
+)
+
+const (
+	viewKeyPrefix       = "post_service:view:"
+	pendingViewsKey     = "post_service:views:pending"
+	flushingViewsKeyFmt = "post_service:views:flushing:%d"
+	// flushingViewsKey is the set of the buffers being flushed.
+	flushingViewsKey = "post_service:views:flushing"
+
+	// staleFlushingAge is when a flushing buffer is considered abandoned by
+	// a flush which failed after taking it, a running flush is done with
+	// its buffer in a few milliseconds.
+	staleFlushingAge = time.Minute
+)
+
+// ViewStorageI buffers post views until they are flushed to Postgres.
+type ViewStorageI interface {
+	// Record counts a view of the post by viewer, the views of the same
+	// viewer are counted once per window. It reports whether the view
+	// was counted.
+	Record(postID int64, viewer string, window time.Duration) (bool, error)
+	// TakePending returns the views counted since the last call and
+	// removes them from the buffer, together with the views left by the
+	// earlier calls which failed. The views it returns must be flushed even
+	// when it fails too.
+	TakePending() (map[int64]int64, error)
+	// AddPending puts views back into the buffer, e.g. after a failed
+	// flush.
+	AddPending(views map[int64]int64) error
+}
+
+type viewStorageRedis struct {
+	client *redis.Client
+}
+
+func NewViewStorage(rdb *redis.Client) ViewStorageI {
+	return &viewStorageRedis{
+		client: rdb,
+	}
+}
+
+func (r *viewStorageRedis) Record(postID int64, viewer string, window time.Duration) (bool, error) {
+	ctx := context.Background()
+
+	key := fmt.Sprintf("%s%d:%s", viewKeyPrefix, postID, viewer)
+	isNew, err := r.client.SetNX(ctx, key, 1, window).Result()
+	if err != nil || !isNew {
+		return false, err
+	}
+
+	err = r.client.HIncrBy(ctx, pendingViewsKey, strconv.FormatInt(postID, 10), 1).Err()
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *viewStorageRedis) TakePending() (map[int64]int64, error) {
+	ctx := context.Background()
+	views := make(map[int64]int64)
+
+	if err := r.take(ctx, pendingViewsKey, views); err != nil {
+		return views, err
+	}
+
+	// A flushing buffer outlives its flush only when the flush failed
+	// after renaming it.
+	keys, err := r.client.SMembers(ctx, flushingViewsKey).Result()
+	if err != nil {
+		return views, err
+	}
+	for _, key := range keys {
+		var createdAt int64
+		if _, err := fmt.Sscanf(key, flushingViewsKeyFmt, &createdAt); err != nil {
+			continue
+		}
+		if time.Since(time.Unix(0, createdAt)) < staleFlushingAge {
+			continue
+		}
+		if err := r.take(ctx, key, views); err != nil {
+			return views, err
+		}
+	}
+
+	return views, nil
+}
+
+// take moves the views of the buffer at key to views. The buffer is
+// renamed first, which is atomic, so views recorded meanwhile go to a new
+// buffer and replicas flushing at the same time never take the same views.
+// The renamed buffer is listed in flushingViewsKey until it is read, a
+// later call takes it if reading it fails.
+func (r *viewStorageRedis) take(ctx context.Context, key string, views map[int64]int64) error {
+	flushingKey := fmt.Sprintf(flushingViewsKeyFmt, time.Now().UnixNano())
+
+	pipe := r.client.TxPipeline()
+	rename := pipe.Rename(ctx, key, flushingKey)
+	pipe.SAdd(ctx, flushingViewsKey, flushingKey)
+	pipe.SRem(ctx, flushingViewsKey, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		if rename.Err() != nil && strings.Contains(rename.Err().Error(), "no such key") {
+			// The commands of a transaction run even if one fails.
+			return r.client.SRem(ctx, flushingViewsKey, flushingKey).Err()
+		}
+		return err
+	}
+
+	values, err := r.client.HGetAll(ctx, flushingKey).Result()
+	if err != nil {
+		return err
+	}
+
+	taken := make(map[int64]int64, len(values))
+	for field, value := range values {
+		postID, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			continue
+		}
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		taken[postID] = count
+	}
+
+	// The views are flushed only if the buffer is gone, otherwise they
+	// would be counted again with it.
+	pipe = r.client.TxPipeline()
+	pipe.Del(ctx, flushingKey)
+	pipe.SRem(ctx, flushingViewsKey, flushingKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	for postID, count := range taken {
+		views[postID] += count
+	}
+
+	return nil
+}
+
+func (r *viewStorageRedis) AddPending(views map[int64]int64) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+	pipe := r.client.TxPipeline()
+	for postID, count := range views {
+		pipe.HIncrBy(ctx, pendingViewsKey, strconv.FormatInt(postID, 10), count)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/mirasildev/medium_post_service/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// CategoryStatsRefresher periodically recomputes the stats of the
+// categories, so listing them stays cheap.
+type CategoryStatsRefresher struct {
+	storage  storage.StorageI
+	logger   *logrus.Logger
+	interval time.Duration
+}
+
+// defaultCategoryStatsInterval replaces an interval which isn't positive.
+const defaultCategoryStatsInterval = 10 * time.Minute
+
+func NewCategoryStatsRefresher(strg storage.StorageI, logger *logrus.Logger, interval time.Duration) *CategoryStatsRefresher {
+	if interval <= 0 {
+		interval = defaultCategoryStatsInterval
+	}
+
+	return &CategoryStatsRefresher{
+		storage:  strg,
+		logger:   logger,
+		interval: interval,
+	}
+}
+
+func (r *CategoryStatsRefresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.refresh()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *CategoryStatsRefresher) refresh() {
+	if err := r.storage.Category().RefreshStats(); err != nil {
+		r.logger.WithError(err).Error("failed to refresh category stats")
+		return
+	}
+	r.logger.Debug("category stats refreshed")
+}
@@ -0,0 +1,13 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/mirasildev/medium_post_service/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCategoryStatsRefresherDefaultsInterval(t *testing.T) {
+	r := NewCategoryStatsRefresher(nil, logger.New(), 0)
+	require.Equal(t, defaultCategoryStatsInterval, r.interval)
+}
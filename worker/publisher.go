@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/mirasildev/medium_post_service/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// Publisher periodically publishes the drafts whose publish_at has passed.
+// Several replicas can run it at the same time, every due post is claimed
+// by exactly one of them.
+type Publisher struct {
+	storage   storage.StorageI
+	logger    *logrus.Logger
+	interval  time.Duration
+	batchSize int
+}
+
+// The defaults replace the values which aren't positive, a zero batch
+// would never end a run and a zero interval can't tick.
+const (
+	defaultPublisherInterval  = time.Minute
+	defaultPublisherBatchSize = 100
+)
+
+func NewPublisher(strg storage.StorageI, logger *logrus.Logger, interval time.Duration, batchSize int) *Publisher {
+	if interval <= 0 {
+		interval = defaultPublisherInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultPublisherBatchSize
+	}
+
+	return &Publisher{
+		storage:   strg,
+		logger:    logger,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+func (p *Publisher) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.publishDue()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Publisher) publishDue() {
+	for {
+		posts, err := p.storage.Post().PublishDue(time.Now(), p.batchSize)
+		if err != nil {
+			p.logger.WithError(err).Error("failed to publish scheduled posts")
+			return
+		}
+
+		for _, post := range posts {
+			p.logger.WithField("post_id", post.ID).Info("scheduled post published")
+		}
+
+		if len(posts) < p.batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/mirasildev/medium_post_service/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// TrashPurger periodically deletes the posts which have been in the trash
+// for longer than the retention, together with their comments and likes.
+// Several replicas can run it at the same time.
+type TrashPurger struct {
+	storage   storage.StorageI
+	logger    *logrus.Logger
+	interval  time.Duration
+	retention time.Duration
+	batchSize int
+}
+
+// The defaults replace the values which aren't positive, a zero retention
+// would purge the posts as soon as they are trashed.
+const (
+	defaultTrashPurgeInterval  = time.Hour
+	defaultTrashRetention      = 30 * 24 * time.Hour
+	defaultTrashPurgeBatchSize = 100
+)
+
+func NewTrashPurger(strg storage.StorageI, logger *logrus.Logger, interval, retention time.Duration, batchSize int) *TrashPurger {
+	if interval <= 0 {
+		interval = defaultTrashPurgeInterval
+	}
+	if retention <= 0 {
+		retention = defaultTrashRetention
+	}
+	if batchSize <= 0 {
+		batchSize = defaultTrashPurgeBatchSize
+	}
+
+	return &TrashPurger{
+		storage:   strg,
+		logger:    logger,
+		interval:  interval,
+		retention: retention,
+		batchSize: batchSize,
+	}
+}
+
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *TrashPurger) purge() {
+	before := time.Now().Add(-p.retention)
+	for {
+		ids, err := p.storage.Post().PurgeDeleted(before, p.batchSize)
+		if err != nil {
+			p.logger.WithError(err).Error("failed to purge trashed posts")
+			return
+		}
+
+		if len(ids) > 0 {
+			p.logger.WithField("post_ids", ids).Info("trashed posts purged")
+		}
+
+		if len(ids) < p.batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/mirasildev/medium_post_service/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// TrendingScorer periodically recomputes the trending scores of the posts,
+// so listing them by score stays a plain index scan.
+type TrendingScorer struct {
+	storage  storage.StorageI
+	logger   *logrus.Logger
+	interval time.Duration
+}
+
+// defaultTrendingInterval replaces an interval which isn't positive, such
+// an interval can't tick.
+const defaultTrendingInterval = 5 * time.Minute
+
+func NewTrendingScorer(strg storage.StorageI, logger *logrus.Logger, interval time.Duration) *TrendingScorer {
+	if interval <= 0 {
+		interval = defaultTrendingInterval
+	}
+
+	return &TrendingScorer{
+		storage:  strg,
+		logger:   logger,
+		interval: interval,
+	}
+}
+
+func (s *TrendingScorer) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.update()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *TrendingScorer) update() {
+	updated, err := s.storage.Post().UpdateTrendingScores(time.Now())
+	if err != nil {
+		s.logger.WithError(err).Error("failed to update trending scores")
+		return
+	}
+	s.logger.WithField("posts", updated).Debug("trending scores updated")
+}
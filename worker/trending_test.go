@@ -0,0 +1,19 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mirasildev/medium_post_service/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTrendingScorerDefaultsInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		s := NewTrendingScorer(nil, logger.New(), interval)
+		require.Equal(t, defaultTrendingInterval, s.interval)
+	}
+
+	s := NewTrendingScorer(nil, logger.New(), time.Minute)
+	require.Equal(t, time.Minute, s.interval)
+}
@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/mirasildev/medium_post_service/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// ViewFlusher periodically moves the views buffered in Redis to the
+// views_count of the posts, batchSize posts per query.
+type ViewFlusher struct {
+	storage   storage.StorageI
+	views     storage.ViewStorageI
+	logger    *logrus.Logger
+	interval  time.Duration
+	batchSize int
+}
+
+// The defaults replace the values which aren't positive.
+const (
+	defaultViewFlushInterval  = 30 * time.Second
+	defaultViewFlushBatchSize = 500
+)
+
+func NewViewFlusher(strg storage.StorageI, views storage.ViewStorageI, logger *logrus.Logger, interval time.Duration, batchSize int) *ViewFlusher {
+	if interval <= 0 {
+		interval = defaultViewFlushInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultViewFlushBatchSize
+	}
+
+	return &ViewFlusher{
+		storage:   strg,
+		views:     views,
+		logger:    logger,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run flushes until ctx is done and once more before it returns.
+func (f *ViewFlusher) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			f.flush()
+			return
+		case <-ticker.C:
+			f.flush()
+		}
+	}
+}
+
+func (f *ViewFlusher) flush() {
+	// The views taken before a failure are flushed, the rest are taken
+	// by a later flush.
+	views, err := f.views.TakePending()
+	if err != nil {
+		f.logger.WithError(err).Error("failed to take pending views")
+	}
+
+	batch := make(map[int64]int64, f.batchSize)
+	for postID, count := range views {
+		batch[postID] = count
+		if len(batch) >= f.batchSize {
+			f.write(batch)
+			batch = make(map[int64]int64, f.batchSize)
+		}
+	}
+	f.write(batch)
+}
+
+// write puts the views back to the buffer when they can't be stored, so
+// they are retried by the next flush.
+func (f *ViewFlusher) write(views map[int64]int64) {
+	if len(views) == 0 {
+		return
+	}
+
+	err := f.storage.Post().AddViews(views)
+	if err == nil {
+		return
+	}
+	f.logger.WithError(err).Error("failed to flush post views")
+
+	if err := f.views.AddPending(views); err != nil {
+		f.logger.WithError(err).WithField("posts", len(views)).Error("failed to return post views to the buffer, they are lost")
+	}
+}
@@ -0,0 +1,14 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/mirasildev/medium_post_service/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewViewFlusherDefaults(t *testing.T) {
+	f := NewViewFlusher(nil, nil, logger.New(), 0, -1)
+	require.Equal(t, defaultViewFlushInterval, f.interval)
+	require.Equal(t, defaultViewFlushBatchSize, f.batchSize)
+}